@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/widget"
+)
+
+// alarmPollInterval is how often the running instance is queried while waiting for the planned exit.
+const alarmPollInterval = 30 * time.Second
+
+// runAlarm implements the alarm command and returns the process exit code.
+func runAlarm(args []string) int {
+	fs := flag.NewFlagSet("alarm", flag.ExitOnError)
+	atExit := fs.Bool("at-exit", false, "ring at the planned exit of the running instance, following its changes")
+	in := fs.Duration("in", 0, "ring after this duration (e.g. 45m)")
+	at := fs.String("at", "", "ring at this time (HH:MM)")
+	message := fs.String("message", "", "text of the notification")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely alarm --at-exit | --in DURATION | --at HH:MM [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	var wait func() error
+	title := "Timely alarm"
+	switch {
+	case *atExit && *in == 0 && *at == "":
+		title = "Planned exit reached"
+		wait = waitPlannedExit
+	case !*atExit && *in > 0 && *at == "":
+		deadline := time.Now().Add(*in)
+		wait = func() error { return sleepUntil(deadline) }
+	case !*atExit && *in == 0 && *at != "":
+		deadline, err := timeutils.ParseTimeOn(*at, homeNow())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unknown time", *at)
+			return 1
+		}
+		wait = func() error { return sleepUntil(deadline) }
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	if err := wait(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	body := *message
+	if body == "" {
+		body = "It is " + timeutils.FormatTime(homeNow())
+	}
+	fmt.Print("\a")
+	fmt.Println(title + ": " + body)
+	if err := platform.Notify(title, body); err != nil {
+		fmt.Fprintln(os.Stderr, "Notification failed:", err)
+	}
+	return 0
+}
+
+// sleepUntil blocks until deadline, which must not be in the past.
+func sleepUntil(deadline time.Time) error {
+	d := time.Until(deadline)
+	if d < 0 {
+		return fmt.Errorf("%s is already past", timeutils.FormatTime(deadline))
+	}
+	time.Sleep(d)
+	return nil
+}
+
+// waitPlannedExit blocks until the running instance reaches its planned exit while
+// clocked in. The planned exit is queried regularly, as breaks postpone it.
+func waitPlannedExit() error {
+	for {
+		status, err := widget.Fetch(widget.SocketPath())
+		if err != nil {
+			return fmt.Errorf("timely is not running: %w", err)
+		}
+		if status.ProvisionalSeconds >= status.TargetSeconds && status.TargetSeconds > 0 {
+			return nil
+		}
+		next := alarmPollInterval
+		if status.ClockedIn && status.PlannedExit != nil {
+			if until := time.Until(*status.PlannedExit); until < next {
+				next = until
+			}
+		}
+		if next <= 0 {
+			return nil
+		}
+		time.Sleep(next)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// runAnnotate implements the annotate command and returns the process exit code.
+func runAnnotate(args []string) int {
+	return runLabel("annotate", "note", "Sets the note of the most recent span, or of the one at --at; an empty note removes it.", args,
+		func(entries tracking.Entries, open int, note string) tracking.Entries {
+			return entries.Annotate(open, note)
+		})
+}
+
+// runTag implements the tag command and returns the process exit code.
+func runTag(args []string) int {
+	billable := parseProjects(flag.Lookup("billable").Value.String())
+	return runLabel("tag", "project", "Books the most recent span, or the one at --at, on a project, a leading # is left out: '#clientX'.", args,
+		func(entries tracking.Entries, open int, project string) tracking.Entries {
+			project = strings.TrimPrefix(project, "#")
+			tagged := slices.Clone(entries)
+			// The clock-out carries the project of its span, as recorded by the tracker
+			for i := open; i < min(open+2, len(tagged)); i++ {
+				tagged[i].Project = project
+			}
+			tagged[open].Billable = tagged[open].Billable || slices.Contains(billable, "*") || (project != "" && slices.Contains(billable, project))
+			return tagged
+		})
+}
+
+// runLabel labels a span of the stored entries of a day with the text given as
+// arguments. label returns the entries in which the span opened by the entry at
+// index open carries the text.
+func runLabel(name, text, summary string, args []string, label func(entries tracking.Entries, open int, text string) tracking.Entries) int {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	date := fs.String("date", "", "day of the span: today, yesterday or YYYY-MM-DD")
+	last := fs.Bool("last", false, "label the most recent span of the day, the default")
+	at := fs.String("at", "", "label the span running at HH:MM instead")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely "+name+" [flags] "+text)
+		fmt.Fprintln(fs.Output(), summary)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *last && *at != "" {
+		fmt.Fprintln(os.Stderr, "--last and --at cannot be combined")
+		return 2
+	}
+	value := strings.TrimSpace(strings.Join(fs.Args(), " "))
+
+	now := homeNow()
+	day := now
+	if *date != "" {
+		var err error
+		if day, err = timeutils.ParseDate(*date, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+		return 1
+	}
+	stored, err := store.Load(day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the entries:", err)
+		return 1
+	}
+	if len(stored.Entries) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing labelled, no entries on "+day.Format("2006-01-02"))
+		return 1
+	}
+
+	open := len(stored.Entries) - 1 - (len(stored.Entries)-1)%2
+	if *at != "" {
+		t, err := timeutils.ParseTimeOn(*at, day)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if open = spanAt(stored.Entries, t); open < 0 {
+			fmt.Fprintln(os.Stderr, "Nothing labelled, no span at "+timeutils.FormatTime(t)+": "+daySummary(stored.Entries))
+			return 1
+		}
+	}
+
+	stored.Entries = label(stored.Entries, open, value)
+	if err := store.Save(day, stored); err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+		return 1
+	}
+	fmt.Println(day.Format("2006-01-02") + " " + daySummary(stored.Entries))
+	return 0
+}
+
+// spanAt returns the index of the entry opening the span running at t, from its start
+// included to its end excluded, or -1 if there is none. The open span runs on.
+func spanAt(entries tracking.Entries, t time.Time) int {
+	for i := 0; i < len(entries); i += 2 {
+		if t.Before(entries[i].Time) {
+			continue
+		}
+		if i+1 == len(entries) || t.Before(entries[i+1].Time) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestSpanAt(t *testing.T) {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	entries := tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{"before the first span", at(7, 59), -1},
+		{"start of a span", at(8, 0), 0},
+		{"within a span", at(11, 59), 0},
+		{"end of a span", at(12, 0), -1},
+		{"open span", at(18, 0), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spanAt(entries, tt.at); got != tt.want {
+				t.Errorf("spanAt(%s) = %d, want %d", tt.at.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
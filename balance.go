@@ -0,0 +1,445 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// adjustmentsFile is the name of the file, within the data directory, keeping the
+// corrections of the balance.
+const adjustmentsFile = "adjustments.json"
+
+// adjustment is a manual correction of the flex balance, e.g. time off taken.
+type adjustment struct {
+	Date    string `json:"date"`
+	Seconds int64  `json:"seconds"`
+	Note    string `json:"note,omitempty"`
+}
+
+// adjustmentsPath returns the file keeping the corrections of the balance.
+func adjustmentsPath() (string, error) {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, adjustmentsFile), nil
+}
+
+// loadAdjustments returns the corrections of the balance, none when the file is missing.
+func loadAdjustments(path string) ([]adjustment, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var adjustments []adjustment
+	if err := json.Unmarshal(b, &adjustments); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return adjustments, nil
+}
+
+// runBalance implements the balance command and returns the process exit code.
+func runBalance(args []string) int {
+	if len(args) > 0 && args[0] == "adjust" {
+		return runAdjust(args[1:])
+	}
+
+	fs := flag.NewFlagSet("balance", flag.ExitOnError)
+	date := fs.String("date", "today", "day reported, or within the week or month reported: today, yesterday or YYYY-MM-DD")
+	from := fs.String("from", "", "first day of the balance: today, yesterday or YYYY-MM-DD")
+	to := fs.String("to", "today", "last day of the balance with --from")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely balance [flags] [day | week | month]")
+		fmt.Fprintln(fs.Output(), "       timely balance adjust [--date DAY] DURATION [note]")
+		fmt.Fprintln(fs.Output(), "Prints the overtime of each day, the corrections and the running flex balance, since the first")
+		fmt.Fprintln(fs.Output(), "day tracked by default. adjust records a correction, e.g. -02:00 for an afternoon taken off.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	period := fs.Arg(0)
+	// Flags are also accepted after the period
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+
+	path, err := adjustmentsPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	adjustments, err := loadAdjustments(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the corrections:", err)
+		return 1
+	}
+
+	now := homeNow()
+	var start, end time.Time
+	if period != "" || *from != "" {
+		if start, end, err = reportPeriod(period, *date, *from, *to, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	} else if start, err = firstTrackedDay(adjustments, now); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	} else {
+		end = timeutils.StartOfDay(now).AddDate(0, 0, 1)
+	}
+
+	noTarget, err := writeBalance(os.Stdout, start, end, now, adjustments)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot compute the balance:", err)
+		return 1
+	}
+	if noTarget > 0 {
+		days := "days"
+		if noTarget == 1 {
+			days = "day"
+		}
+		fmt.Fprintf(os.Stderr, "%d %s without target left out, set one with --target or --work-week\n", noTarget, days)
+	}
+	return 0
+}
+
+// firstTrackedDay returns the first day stored or corrected, today when there is none.
+func firstTrackedDay(adjustments []adjustment, now time.Time) (time.Time, error) {
+	first := timeutils.StartOfDay(now)
+	store, err := dayStore()
+	if err != nil {
+		return time.Time{}, err
+	}
+	days, err := store.Days()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(days) > 0 && days[0].Before(first) {
+		first = days[0]
+	}
+	for _, a := range adjustments {
+		if day, err := time.ParseInLocation("2006-01-02", a.Date, now.Location()); err == nil && day.Before(first) {
+			first = day
+		}
+	}
+	return first, nil
+}
+
+// writeBalance writes the overtime and the corrections of each day from start until
+// end, with the running balance, and returns the number of days left out as their
+// target is unknown.
+func writeBalance(out io.Writer, start, end, now time.Time, adjustments []adjustment) (int, error) {
+	days, noTarget, err := balanceDays(start, end, now, adjustments)
+	if err != nil {
+		return 0, err
+	}
+
+	dates := make([]string, 0, len(days))
+	for date := range days {
+		dates = append(dates, date)
+	}
+	slices.Sort(dates)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tovertime\tadjusted\tbalance\t")
+	var overtime, adjusted time.Duration
+	for _, date := range dates {
+		d := days[date]
+		overtime += d.overtime
+		adjusted += d.adjusted
+		correction := ""
+		if d.adjusted != 0 {
+			correction = timeutils.FormatDuration(d.adjusted)
+		}
+		worked := timeutils.FormatDuration(d.overtime)
+		notes := d.notes
+		if d.noTarget {
+			worked, notes = "", append([]string{"no target, left out"}, notes...)
+		}
+		if d.untracked {
+			notes = append([]string{"nothing tracked"}, notes...)
+		}
+		t, _ := time.Parse("2006-01-02", date)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t  %s\n", t.Format("Mon 2006-01-02"), worked, correction,
+			timeutils.FormatDuration(overtime+adjusted), strings.Join(notes, "; "))
+	}
+	// The balance carries over from one year to the next
+	var balance time.Duration
+	for _, year := range yearTotals(days, start, end) {
+		balance += year.overtime + year.adjusted
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t\n", year.year, timeutils.FormatDuration(year.overtime), timeutils.FormatDuration(year.adjusted),
+			timeutils.FormatDuration(balance))
+	}
+	fmt.Fprintf(w, "total\t%s\t%s\t%s\t\n", timeutils.FormatDuration(overtime), timeutils.FormatDuration(adjusted),
+		timeutils.FormatDuration(overtime+adjusted))
+	return noTarget, w.Flush()
+}
+
+// balanceDays returns the overtime and the corrections of each day from start until
+// end, keyed by date, and the number of days left out as their target is unknown.
+func balanceDays(start, end, now time.Time, adjustments []adjustment) (map[string]*balanceDay, int, error) {
+	days := make(map[string]*balanceDay)
+	day := func(date string) *balanceDay {
+		if days[date] == nil {
+			days[date] = &balanceDay{}
+		}
+		return days[date]
+	}
+	// Without target, the time worked of a day is not overtime: the day is left out
+	var noTarget int
+	err := eachReportDay(start, end, now, func(line reportLine) {
+		if line.NoTarget {
+			day(line.Date).noTarget = true
+			noTarget++
+			return
+		}
+		day(line.Date).overtime = line.Overtime
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	// The working days on which nothing was tracked owe their target, from the
+	// first day tracked on and until today
+	err = eachUntrackedDay(start, end, now, func(date string, target time.Duration) {
+		d := day(date)
+		d.overtime, d.untracked = -target, true
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	first, last := start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02")
+	for _, a := range adjustments {
+		if a.Date < first || a.Date > last {
+			continue
+		}
+		d := day(a.Date)
+		d.adjusted += time.Duration(a.Seconds) * time.Second
+		if a.Note != "" {
+			d.notes = append(d.notes, a.Note)
+		}
+	}
+	return days, noTarget, nil
+}
+
+// eachUntrackedDay calls fn with the date and the target of each day from start until
+// end, and before the day of now, with a target but nothing tracked, once a first day
+// was tracked. Holidays and days off have no target.
+func eachUntrackedDay(start, end, now time.Time, fn func(date string, target time.Duration)) error {
+	fullDay, err := time.ParseDuration(flag.Lookup("full-day").Value.String())
+	if err != nil {
+		return err
+	}
+	week, err := parseWorkWeek(flag.Lookup("work-week").Value.String(), fullDay)
+	if err != nil {
+		return err
+	}
+	store, err := dayStore()
+	if err != nil {
+		return err
+	}
+	tracked, err := store.Days()
+	if err != nil || len(tracked) == 0 {
+		return err
+	}
+	if tracked[0].After(start) {
+		start = tracked[0]
+	}
+	today := timeutils.StartOfDay(now)
+	for day := start; day.Before(end) && day.Before(today); day = day.AddDate(0, 0, 1) {
+		stored, err := store.Load(day)
+		if err != nil {
+			return err
+		}
+		if len(stored.Entries) > 0 || stored.Target > 0 {
+			continue
+		}
+		target, _, err := todayTarget(week, flag.Lookup("target").Value.String(), fullDay, day)
+		if err != nil {
+			return err
+		}
+		if target > 0 {
+			fn(day.Format("2006-01-02"), target)
+		}
+	}
+	return nil
+}
+
+// carriedBalance returns the flex balance carried over to the day of now: the
+// overtime of the days before it, with the corrections up to it included. found is
+// false when nothing was tracked or corrected before.
+func carriedBalance(now time.Time) (balance time.Duration, found bool, err error) {
+	path, err := adjustmentsPath()
+	if err != nil {
+		return 0, false, err
+	}
+	adjustments, err := loadAdjustments(path)
+	if err != nil {
+		return 0, false, err
+	}
+	start, err := firstTrackedDay(adjustments, now)
+	if err != nil {
+		return 0, false, err
+	}
+	today := timeutils.StartOfDay(now)
+	days, _, err := balanceDays(start, today, now, adjustments)
+	if err != nil {
+		return 0, false, err
+	}
+	date := today.Format("2006-01-02")
+	for _, a := range adjustments {
+		if a.Date == date {
+			balance += time.Duration(a.Seconds) * time.Second
+			found = true
+		}
+	}
+	for _, d := range days {
+		balance += d.overtime + d.adjusted
+		found = true
+	}
+	return balance, found, nil
+}
+
+// balanceLoaded carries the balance carried over to today, see carriedBalance.
+type balanceLoaded struct {
+	balance time.Duration
+	found   bool
+}
+
+// loadBalance computes the balance carried over to the day of now in the background.
+// Failures leave the balance out of the header, timely balance reports them.
+func loadBalance(now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		balance, found, err := carriedBalance(now)
+		return balanceLoaded{balance: balance, found: found && err == nil}
+	}
+}
+
+// balanceDay holds the overtime and the corrections of a day of the balance.
+type balanceDay struct {
+	overtime, adjusted time.Duration
+	noTarget           bool
+	untracked          bool // a working day on which nothing was tracked, owing its target
+	notes              []string
+}
+
+// yearTotal holds the overtime and the corrections of a calendar year.
+type yearTotal struct {
+	year               int
+	overtime, adjusted time.Duration
+}
+
+// yearTotals returns the totals of each calendar year of the days, keyed by date, from
+// start until end. There are none when the period is within a single year.
+func yearTotals(days map[string]*balanceDay, start, end time.Time) []yearTotal {
+	years := timeutils.SplitByYear(timeutils.Span{Start: start, End: end})
+	if len(years) < 2 {
+		return nil
+	}
+	var totals []yearTotal
+	for _, year := range years {
+		first, next := year.Start.Format("2006-01-02"), year.End.Format("2006-01-02")
+		total := yearTotal{year: year.Start.Year()}
+		for date, d := range days {
+			if date >= first && date < next {
+				total.overtime += d.overtime
+				total.adjusted += d.adjusted
+			}
+		}
+		totals = append(totals, total)
+	}
+	return totals
+}
+
+// runAdjust implements balance adjust, recording a correction of the balance, and
+// returns the process exit code.
+func runAdjust(args []string) int {
+	fs := flag.NewFlagSet("balance adjust", flag.ExitOnError)
+	date := fs.String("date", "today", "day of the correction: today, yesterday or YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely balance adjust [flags] DURATION [note]")
+		fmt.Fprintln(fs.Output(), "Records a correction of the flex balance, e.g. -02:00 or +1h30m, with an optional note.")
+		fs.PrintDefaults()
+	}
+	// A negative duration is not a flag: the flags are the arguments before it
+	i := slices.IndexFunc(args, func(arg string) bool { return strings.TrimLeft(arg, "+-") != arg && looksLikeDuration(arg) })
+	if i < 0 {
+		i = len(args)
+	}
+	fs.Parse(args[:i])
+	rest := append(fs.Args(), args[i:]...)
+	if len(rest) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	d, err := parseSignedDuration(rest[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	day, err := timeutils.ParseDate(*date, homeNow())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	path, err := adjustmentsPath()
+	var adjustments []adjustment
+	if err == nil {
+		adjustments, err = loadAdjustments(path)
+	}
+	if err == nil {
+		adjustments = append(adjustments, adjustment{Date: day.Format("2006-01-02"), Seconds: int64(d.Seconds()), Note: strings.Join(rest[1:], " ")})
+		var b []byte
+		if b, err = json.MarshalIndent(adjustments, "", "  "); err == nil {
+			if err = os.MkdirAll(filepath.Dir(path), 0o700); err == nil {
+				err = os.WriteFile(path, append(b, '\n'), 0o600)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot record the correction:", err)
+		return 1
+	}
+	fmt.Println("Balance adjusted by " + timeutils.FormatDuration(d) + " on " + day.Format("2006-01-02"))
+	return 0
+}
+
+// looksLikeDuration reports whether s starts like a duration once signed: "-02:00".
+func looksLikeDuration(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// parseSignedDuration parses a duration, optionally signed, as HH:MM or as a Go
+// duration: "-02:00", "+1:30", "-2h" or "45m".
+func parseSignedDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	sign := time.Duration(1)
+	rest := strings.TrimPrefix(s, "+")
+	if r, ok := strings.CutPrefix(rest, "-"); ok {
+		sign, rest = -1, r
+	}
+	t, err := timeutils.ParseTime(rest)
+	if err != nil {
+		return 0, fmt.Errorf("%s: not a duration, use HH:MM or e.g. 1h30m, signed for a deduction", s)
+	}
+	return sign * (time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+}
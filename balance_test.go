@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYearTotals(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+	}
+	days := map[string]*balanceDay{
+		"2025-12-30": {overtime: time.Hour},
+		"2025-12-31": {overtime: 30 * time.Minute, adjusted: -2 * time.Hour},
+		"2026-01-01": {adjusted: time.Hour},
+		"2026-01-02": {overtime: -15 * time.Minute},
+	}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       []yearTotal
+	}{
+		{"within a year", day(2025, time.December, 1), day(2026, time.January, 1), nil},
+		{"new year", day(2025, time.December, 30), day(2026, time.January, 3), []yearTotal{
+			{2025, 90 * time.Minute, -2 * time.Hour},
+			{2026, -15 * time.Minute, time.Hour},
+		}},
+		{"year without days", day(2024, time.June, 1), day(2026, time.January, 2), []yearTotal{
+			{2024, 0, 0},
+			{2025, 90 * time.Minute, -2 * time.Hour},
+			{2026, 0, time.Hour},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := yearTotals(days, tt.start, tt.end)
+			if len(got) != len(tt.want) {
+				t.Fatalf("yearTotals() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("yearTotals()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSignedDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"-02:00", -2 * time.Hour, false},
+		{"+1:30", 90 * time.Minute, false},
+		{"1:30", 90 * time.Minute, false},
+		{"0130", 90 * time.Minute, false},
+		{"-2h", -2 * time.Hour, false},
+		{"+1h30m", 90 * time.Minute, false},
+		{"45m", 45 * time.Minute, false},
+		{"-0:05", -5 * time.Minute, false},
+		{"", 0, true},
+		{"-", 0, true},
+		{"--1:00", 0, true},
+		{"two hours", 0, true},
+		{"-25:00", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSignedDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSignedDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseSignedDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// heatLevels are the marks of the days of the calendar, from a day not tracked to a
+// reached target, so that the heat reads without colors.
+var heatLevels = [...]string{"·", "░", "▒", "█"}
+
+// monthLoaded carries the figures of the days of a month, see loadMonth.
+type monthLoaded struct {
+	month time.Time // midnight of the first day of the month
+	days  []reportLine
+	found bool
+}
+
+// startOfMonth returns midnight of the first day of the month of t.
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// loadMonth reads the figures of the days of the month of day tracked before the day
+// of now in the background, the tracker counting today as it goes.
+func loadMonth(day, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		month := startOfMonth(day)
+		var days []reportLine
+		end := month.AddDate(0, 1, 0)
+		if today := timeutils.StartOfDay(now); today.Before(end) {
+			end = today
+		}
+		err := eachReportDay(month, end, now, func(line reportLine) {
+			days = append(days, line)
+		})
+		return monthLoaded{month: month, days: days, found: err == nil}
+	}
+}
+
+// updateCalendar moves the selection of the calendar with the arrow keys, loading
+// the month it lands in, and shows the day selected on enter.
+func (m model) updateCalendar(msg tea.KeyMsg) (model, tea.Cmd) {
+	selected := m.calendarDay
+	switch {
+	case key.Matches(msg, m.keys.Left):
+		selected = selected.AddDate(0, 0, -1)
+	case key.Matches(msg, m.keys.Right):
+		selected = selected.AddDate(0, 0, 1)
+	case key.Matches(msg, m.keys.Up):
+		selected = selected.AddDate(0, 0, -7)
+	case key.Matches(msg, m.keys.Down):
+		selected = selected.AddDate(0, 0, 7)
+	case key.Matches(msg, m.keys.Add):
+		m.screen = trackerScreen
+		shown := m.ShowDay(m.calendarDay)
+		shown.screen = trackerScreen
+		return shown, nil
+	default:
+		return m, nil
+	}
+	m.calendarDay = selected
+	if !startOfMonth(selected).Equal(startOfMonth(m.month.month)) {
+		return m, loadMonth(selected, m.clock())
+	}
+	return m, nil
+}
+
+// heatLevel returns the index in heatLevels of the figures of a day.
+func heatLevel(line reportLine, tracked bool) int {
+	switch {
+	case !tracked || line.Worked == 0:
+		return 0
+	case line.Target <= 0 || line.Worked >= line.Target:
+		return 3
+	case line.Worked*2 >= line.Target:
+		return 2
+	}
+	return 1
+}
+
+// calendarView renders the month of the selected day as a grid of weeks, each day
+// colored by the time worked against its target, with the figures of the selection.
+func (m model) calendarView() string {
+	month := startOfMonth(m.calendarDay)
+	b := strings.Builder{}
+	b.WriteString(docHeadingStyle.Render(strings.ToUpper(formatDate(month, "Jan 2006"))) + "\n\n")
+	if !m.month.found || !m.month.month.Equal(month) {
+		return b.String() + helperStyle.Render(tr("loading…")) + "\n"
+	}
+
+	start := timeutils.WeekOf(month).Start(month.Location())
+	for day := start; day.Before(start.AddDate(0, 0, 7)); day = day.AddDate(0, 0, 1) {
+		b.WriteString(helperStyle.Render(" " + string([]rune(formatDate(day, "Mon"))[:2]) + "  "))
+	}
+	b.WriteString("\n")
+	styles := [...]lipgloss.Style{helperStyle, unreachedStyle, warningStyle, reachedStyle}
+	next := month.AddDate(0, 1, 0)
+	for day := start; day.Before(next); day = day.AddDate(0, 0, 1) {
+		if day.Before(month) {
+			b.WriteString("     ")
+			continue
+		}
+		line, tracked := m.lineOn(m.month.days, day)
+		level := heatLevel(line, tracked)
+		cell := styles[level].Render(day.Format("_2") + heatLevels[level])
+		if timeutils.SameDay(day, m.calendarDay) {
+			cell = selectedItemStyle.UnsetPaddingLeft().Render("[") + cell + selectedItemStyle.UnsetPaddingLeft().Render("]")
+		} else {
+			cell = " " + cell + " "
+		}
+		b.WriteString(cell)
+		if day.Weekday() == time.Sunday {
+			b.WriteString("\n")
+		}
+	}
+	if next.Weekday() != time.Monday {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	label := formatDate(m.calendarDay, "Mon 2 Jan")
+	if line, tracked := m.lineOn(m.month.days, m.calendarDay); tracked {
+		b.WriteString(m.weekRow(label, line) + "\n")
+	} else {
+		b.WriteString(helperStyle.Render(label+" "+tr("not tracked")) + "\n")
+	}
+	return b.String() + helperStyle.Render(strings.Join([]string{
+		heatLevels[1] + " " + tr("under half"), heatLevels[2] + " " + tr("under the target"), heatLevels[3] + " " + tr("target reached"),
+	}, " • ")) + "\n"
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestHeatLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    reportLine
+		tracked bool
+		want    int
+	}{
+		{"not tracked", reportLine{}, false, 0},
+		{"nothing worked", reportLine{Target: 8 * time.Hour}, true, 0},
+		{"under half", reportLine{Worked: 3 * time.Hour, Target: 8 * time.Hour}, true, 1},
+		{"half", reportLine{Worked: 4 * time.Hour, Target: 8 * time.Hour}, true, 2},
+		{"reached", reportLine{Worked: 9 * time.Hour, Target: 8 * time.Hour}, true, 3},
+		{"day off worked", reportLine{Worked: time.Hour}, true, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := heatLevel(tt.line, tt.tracked); got != tt.want {
+				t.Errorf("heatLevel() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarView(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	setFlags(t, map[string]string{"full-day": "8h", "work-week": "", "target": "8:00"})
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	span := func(day string, hours int) tracking.Day {
+		start, _ := time.ParseInLocation("2006-01-02 15:04", day+" 08:00", time.Local)
+		stored := tracking.Day{Entries: tracking.Entries{{Time: start}, {Time: start.Add(time.Duration(hours) * time.Hour)}}}
+		if err := store.Save(start, stored); err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+	span("2025-03-10", 4)
+	stored := span("2025-03-11", 9)
+	span("2025-03-14", 2)
+	day := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.Local)
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, day, stored)
+	press := func(k tea.KeyType) tea.Cmd {
+		updated, cmd := m.Update(tea.KeyMsg{Type: k})
+		m = updated.(model)
+		return cmd
+	}
+
+	// The calendar follows the week view, on the month of the day shown
+	press(tea.KeyTab)
+	cmd := press(tea.KeyTab)
+	if m.screen != calendarScreen || cmd == nil {
+		t.Fatalf("tab twice shows screen %d, want the calendar", m.screen)
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(model)
+	view := m.View()
+	for _, want := range []string{"MAR 2025", " 1·", "10▒", "[11█]", "14░", "31·", "Tue 11 Mar  09:00 / 08:00"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("calendar lacks %q:\n%s", want, view)
+		}
+	}
+
+	// The arrows move the selection, loading the month it lands in
+	press(tea.KeyDown)
+	press(tea.KeyRight)
+	if !m.calendarDay.Equal(day.AddDate(0, 0, 8)) || !strings.Contains(m.View(), "Wed 19 Mar not tracked") {
+		t.Errorf("down and right select %s, want 2025-03-19:\n%s", m.calendarDay, m.View())
+	}
+	press(tea.KeyUp)
+	press(tea.KeyUp)
+	press(tea.KeyUp)
+	if cmd := press(tea.KeyLeft); cmd == nil || !m.calendarDay.Equal(time.Date(2025, time.February, 25, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("up three times and left select %s, want February loaded", m.calendarDay)
+	}
+
+	// enter shows the day selected
+	press(tea.KeyRight)
+	press(tea.KeyRight)
+	press(tea.KeyRight)
+	press(tea.KeyRight)
+	press(tea.KeyDown)
+	press(tea.KeyRight)
+	press(tea.KeyRight)
+	press(tea.KeyEnter)
+	if m.screen != trackerScreen || !m.day.Equal(day.AddDate(0, 0, -1)) || len(m.entries) != 2 {
+		t.Errorf("enter shows %s on screen %d, want 2025-03-10 on the tracker", m.day, m.screen)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// runStart implements the start command and returns the process exit code.
+func runStart(args []string) int {
+	return runClock("start", "Clocks in at the current time, fails when already clocked in.", args, func(in bool) (bool, string) {
+		return !in, "already clocked in"
+	})
+}
+
+// runStop implements the stop command and returns the process exit code.
+func runStop(args []string) int {
+	return runClock("stop", "Clocks out at the current time, fails when not clocked in.", args, func(in bool) (bool, string) {
+		return in, "not clocked in"
+	})
+}
+
+// runToggle implements the toggle command and returns the process exit code.
+func runToggle(args []string) int {
+	return runClock("toggle", "Clocks in or out at the current time.", args, func(bool) (bool, string) {
+		return true, ""
+	})
+}
+
+// runClock records the current time, or the one given, in the stored entries of its
+// day. allowed tells, from whether the day is clocked in, if the command applies or
+// why it does not.
+func runClock(name, summary string, args []string, allowed func(in bool) (bool, string)) int {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	date := fs.String("date", "", "day of the entry: today, yesterday or YYYY-MM-DD, a time must then be given")
+	note := fs.String("note", "", "note of the entry recorded")
+	project := fs.String("project", "", "project of the entry recorded")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely "+name+" [flags] [HH:MM]")
+		fmt.Fprintln(fs.Output(), summary+" A time records it then instead, e.g. a forgotten clock out.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	given := fs.Arg(0)
+	// Flags are also accepted after the time
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+
+	now := homeNow().Truncate(time.Minute)
+	at := now
+	if given != "" {
+		t, err := timeutils.ParseTimeOn(given, now)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		at = t
+	}
+	if *date != "" {
+		if given == "" {
+			fmt.Fprintln(os.Stderr, "--date needs the time of the entry, e.g. timely "+name+" --date yesterday 17:30")
+			return 2
+		}
+		day, err := timeutils.ParseDate(*date, now)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		at = timeutils.OnDay(at, day)
+	}
+
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+		return 1
+	}
+	day, err := store.Load(at)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the entries:", err)
+		return 1
+	}
+	in := len(day.Entries)%2 == 1
+	if ok, reason := allowed(in); !ok {
+		fmt.Fprintln(os.Stderr, "Nothing recorded, "+reason+": "+daySummary(day.Entries))
+		return 1
+	}
+	// An entry after the one recorded would be paired with it instead
+	if last := day.Entries.Last().Time; len(day.Entries) > 0 && !last.Before(at) {
+		fmt.Fprintln(os.Stderr, "Nothing recorded, the last entry is at "+timeutils.FormatTime(last)+", not before "+timeutils.FormatTime(at))
+		return 1
+	}
+
+	day.Entries = day.Entries.Add(tracking.Entry{Time: at, Note: *note, Project: *project})
+	if err := store.Save(at, day); err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+		return 1
+	}
+	state := "clocked in"
+	if in {
+		state = "clocked out"
+	}
+	fmt.Println(state + " at " + at.Format("2006-01-02 15:04") + ", " + daySummary(day.Entries))
+	return 0
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestRunClock(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	// The steps run in turn on the same day
+	tests := []struct {
+		name string
+		run  func([]string) int
+		args []string
+		want int
+		// wantEntries are the stored entries after the step
+		wantEntries tracking.Entries
+	}{
+		{"start", runStart, []string{"--date", "2025-03-14", "08:00"}, 0, tracking.Entries{{Time: at(8, 0)}}},
+		{"start while clocked in", runStart, []string{"--date", "2025-03-14", "09:00"}, 1, tracking.Entries{{Time: at(8, 0)}}},
+		{"stop before the last entry", runStop, []string{"--date", "2025-03-14", "07:30"}, 1, tracking.Entries{{Time: at(8, 0)}}},
+		{"stop with a note after the time", runStop, []string{"12:00", "--date", "2025-03-14", "--note", "lunch"}, 0,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}}},
+		{"stop while clocked out", runStop, []string{"--date", "2025-03-14", "12:30"}, 1,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}}},
+		{"toggle in on a project", runToggle, []string{"--date", "2025-03-14", "--project", "acme", "13:00"}, 0,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}, {Time: at(13, 0), Project: "acme"}}},
+		{"date without time", runStop, []string{"--date", "2025-03-14"}, 2,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}, {Time: at(13, 0), Project: "acme"}}},
+		{"invalid time", runStop, []string{"--date", "2025-03-14", "noon"}, 2,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}, {Time: at(13, 0), Project: "acme"}}},
+		{"invalid date", runStop, []string{"--date", "14.03.2025", "17:00"}, 2,
+			tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0), Note: "lunch"}, {Time: at(13, 0), Project: "acme"}}},
+	}
+
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.run(tt.args); got != tt.want {
+				t.Errorf("exit code = %d, want %d", got, tt.want)
+			}
+			stored, err := store.Load(day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !stored.Entries.Equal(tt.wantEntries) || stored.Entries[len(stored.Entries)-1] != tt.wantEntries[len(tt.wantEntries)-1] {
+				t.Errorf("stored %v, want %v", stored.Entries, tt.wantEntries)
+			}
+		})
+	}
+}
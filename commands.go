@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// command is a subcommand of timely, run in place of the tracker.
+type command struct {
+	name    string
+	args    string // arguments, as displayed in the usage
+	summary string
+	// run runs the command with its arguments and returns the process exit code
+	run func(args []string) int
+}
+
+// commands returns the subcommands, in the order of the usage.
+func commands() []command {
+	return []command{
+		{name: "add", args: "[--date DAY] [times]", summary: "record times, or the current time, without opening the tracker", run: runAdd},
+		{name: "edit", args: "[--date DAY]", summary: "correct the stored entries of a day in a text editor", run: runEdit},
+		{name: "undo", args: "", summary: "revert the last change of the stored entries", run: runUndo},
+		{name: "start", args: "[flags] [HH:MM]", summary: "clock in, fails when already clocked in", run: runStart},
+		{name: "stop", args: "[flags] [HH:MM]", summary: "clock out, fails when not clocked in", run: runStop},
+		{name: "toggle", args: "[flags] [HH:MM]", summary: "clock in or out", run: runToggle},
+		{name: "annotate", args: "[flags] note", summary: "set the note of the most recent span", run: runAnnotate},
+		{name: "tag", args: "[flags] project", summary: "book the most recent span on a project", run: runTag},
+		{name: "meta", args: "[--date DAY] [key=value ...]", summary: "attach key-value metadata to a day, e.g. a mood or ticket ids", run: runMeta},
+		{name: "status", args: "[--format FORMAT] [--template text] [--quiet]", summary: "print the time worked, the target, the overtime and the planned exit", run: runStatus},
+		{name: "watch", args: "[--interval 5s] [--format FORMAT] [--template text]", summary: "print the status again every few seconds, until interrupted", run: runWatch},
+		{name: "report", args: "[flags] [day | week | month]", summary: "print the figures of a day, or of each day of a week, a month or a range", run: runReport},
+		{name: "balance", args: "[flags] [day | week | month] | adjust DURATION [note]", summary: "print the running flex balance, or record a correction", run: runBalance},
+		{name: "export", args: "[--format csv|json|ics|html-timeline|timeclock] [--from DAY] [--to DAY]", summary: "print the stored entries for spreadsheets, other tools or calendars", run: runExport},
+		{name: "import", args: "[--format csv|json|toggl|timeclock] [--dry-run] [file]", summary: "add the spans of a CSV file or of other trackers to the stored entries", run: runImport},
+		{name: "holidays", args: "[list | add | remove | import] [flags]", summary: "manage the public holidays and vacation days, which have no target", run: runHolidays},
+		{name: "stopwatch", args: "[flags] [label]", summary: "elapsed timer for ad-hoc measurements", run: runStopwatch},
+		{name: "alarm", args: "--at-exit | --in DURATION | --at HH:MM", summary: "ring at the planned exit, after a duration or at a time", run: runAlarm},
+		{name: "sum", args: "[--now] < times", summary: "print the total of the paired times read from stdin", run: runSum},
+		{name: "help", args: "[command]", summary: "show the help of timely or of a command", run: runHelp},
+	}
+}
+
+// findCommand returns the subcommand named name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands() {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// usage prints the usage of timely: its forms, the subcommands and the flags.
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: timely [flags] [HH:MM | NN% | until HH:MM]")
+	fmt.Fprintln(out, "       timely [flags] <command> [arguments]")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, c := range commands() {
+		fmt.Fprintf(out, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(out, "\nRun timely help <command> for the arguments and flags of a command.")
+	if path, err := configPath(); err == nil {
+		fmt.Fprintln(out, "The flags default to the settings of "+path+", see --config.")
+	}
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+// runHelp implements the help command and returns the process exit code.
+func runHelp(args []string) int {
+	if len(args) == 0 {
+		flag.CommandLine.SetOutput(os.Stdout)
+		usage()
+		return 0
+	}
+	c, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q, run timely help for the list of commands\n", args[0])
+		return 2
+	}
+	if c.name == "help" {
+		fmt.Println("Usage: timely help " + c.args)
+		return 0
+	}
+	// The flag sets of the commands print their usage and exit on -h
+	return c.run([]string{"-h"})
+}
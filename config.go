@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fredjeck/timely/pkg/platform"
+)
+
+// configFile is the name of the configuration file, within the configuration directory.
+const configFile = "config.toml"
+
+// configPathEnv is the environment variable overriding the path of the configuration file.
+const configPathEnv = "TIMELY_CONFIG"
+
+// envSettings maps the environment variables to the settings they default, below
+// the configuration file.
+var envSettings = []struct{ env, name string }{
+	{"TIMELY_TARGET", "target"},
+	{platform.DataDirEnv, "data-dir"},
+	{"TIMELY_THEME", "theme"},
+}
+
+// setting is a line of the configuration file, named after the command line flag it sets.
+type setting struct {
+	name, value string
+}
+
+// configPath returns the path of the configuration file, TIMELY_CONFIG or
+// timely/config.toml in the configuration directory of the user (e.g. ~/.config on Linux).
+func configPath() (string, error) {
+	if path := os.Getenv(configPathEnv); path != "" {
+		return expandHome(path), nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "timely", configFile), nil
+}
+
+// readConfig parses a configuration made of `name = value` lines, a subset of TOML.
+// Values are either quoted strings or bare words (e.g. true), # starts a comment.
+func readConfig(r io.Reader) ([]setting, error) {
+	var settings []setting
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected name = value", n)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			quoted, err := strconv.QuotedPrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: unterminated string", n)
+			}
+			rest := strings.TrimSpace(value[len(quoted):])
+			if rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("line %d: unexpected %q after the value", n, rest)
+			}
+			value, _ = strconv.Unquote(quoted)
+		} else if before, _, found := strings.Cut(value, "#"); found {
+			value = strings.TrimSpace(before)
+		}
+		settings = append(settings, setting{name: name, value: value})
+	}
+	return settings, scanner.Err()
+}
+
+// loadConfig reads the configuration file at path, a missing file is an empty configuration.
+func loadConfig(path string) ([]setting, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	settings, err := readConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// applyConfig sets the flags of fs from settings, flags given on the command line win.
+func applyConfig(fs *flag.FlagSet, settings []setting) error {
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for _, s := range settings {
+		if fs.Lookup(s.name) == nil {
+			return fmt.Errorf("unknown setting %q", s.name)
+		}
+		if given[s.name] {
+			continue
+		}
+		if err := fs.Set(s.name, s.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// envConfig returns the settings given by environment variables.
+func envConfig() []setting {
+	var settings []setting
+	for _, e := range envSettings {
+		if value := os.Getenv(e.env); value != "" {
+			settings = append(settings, setting{name: e.name, value: value})
+		}
+	}
+	return settings
+}
+
+// writeConfig writes settings to the configuration file at path, creating its directory.
+func writeConfig(path string, settings []setting) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString("# timely configuration, settings are named after the command line flags\n")
+	b.WriteString("# (see timely --help), which override them.\n")
+	for _, s := range settings {
+		b.WriteString(s.name + " = " + strconv.Quote(s.value) + "\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o600)
+}
+
+// expandHome replaces a leading ~ in path with the home directory of the user, the
+// shell does not expand it in the configuration file.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/") && !strings.HasPrefix(rest, string(filepath.Separator))) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
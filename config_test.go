@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/fredjeck/timely/pkg/platform"
+)
+
+func TestEnvConfig(t *testing.T) {
+	t.Setenv("TIMELY_TARGET", "7:00")
+	t.Setenv(platform.DataDirEnv, "/tmp/timely")
+	t.Setenv("TIMELY_THEME", "")
+	got := envConfig()
+	want := []setting{{"target", "7:00"}, {"data-dir", "/tmp/timely"}}
+	if len(got) != len(want) {
+		t.Fatalf("envConfig() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("envConfig()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestConfigPrecedence applies the settings in the order of main: the command line
+// wins over the configuration file, which wins over the environment.
+func TestConfigPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		file       string
+		args       []string
+		wantTarget string
+	}{
+		{"default", "", "", nil, ""},
+		{"environment", "7:00", "", nil, "7:00"},
+		{"file over environment", "7:00", `target = "6:00"`, nil, "6:00"},
+		{"command line over file and environment", "7:00", `target = "6:00"`, []string{"--target", "5:00"}, "5:00"},
+		{"command line over environment", "7:00", "", []string{"--target", "5:00"}, "5:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TIMELY_TARGET", tt.env)
+			t.Setenv(platform.DataDirEnv, "")
+			t.Setenv("TIMELY_THEME", "")
+			fs := flag.NewFlagSet("timely", flag.ContinueOnError)
+			target := fs.String("target", "", "")
+			fs.String("data-dir", "", "")
+			fs.String("theme", "", "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			settings, err := readConfig(strings.NewReader(tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := applyConfig(fs, settings); err != nil {
+				t.Fatal(err)
+			}
+			if err := applyConfig(fs, envConfig()); err != nil {
+				t.Fatal(err)
+			}
+			if *target != tt.wantTarget {
+				t.Errorf("target = %q, want %q", *target, tt.wantTarget)
+			}
+		})
+	}
+}
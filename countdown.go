@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// bigGlyphs draws the characters of the countdown over five lines.
+var bigGlyphs = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" █ ", "██ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	':': {" ", "█", " ", "█", " "},
+	'+': {"   ", " █ ", "███", " █ ", "   "},
+	'-': {"   ", "   ", "███", "   ", "   "},
+}
+
+// bigText renders s with bigGlyphs, unknown characters are skipped.
+func bigText(s string) string {
+	var lines [5]strings.Builder
+	for _, r := range s {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for i, row := range glyph {
+			lines[i].WriteString(row + " ")
+		}
+	}
+	rows := make([]string, len(lines))
+	for i := range lines {
+		rows[i] = lines[i].String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// countdownView renders the time left until the target in large digits along
+// with the planned exit, replacing the entry list.
+func (m model) countdownView() string {
+	remaining := m.target - m.totalProvisionnal
+	var digits, caption string
+	switch {
+	case remaining < 0:
+		digits = "+" + timeutils.FormatDuration(-remaining)
+		caption = helperStyle.Render(tr("overtime, target reached"))
+	case len(m.entries)%2 == 1:
+		digits = timeutils.FormatDuration(remaining)
+		caption = helperStyle.Render(tr("remaining — leave at ")) + m.accentStyle().Render(m.planned)
+	default:
+		digits = timeutils.FormatDuration(remaining)
+		caption = helperStyle.Render(tr("remaining — on a break, clock in to resume"))
+	}
+
+	style := m.accentStyle()
+	if remaining < 0 {
+		style = unreachedStyle
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, "", style.Render(bigText(digits)), "", caption, "")
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// docsFS holds the user documentation bundled in the binary, pages are
+// displayed in file name order.
+//
+//go:embed docs/*.md
+var docsFS embed.FS
+
+var (
+	docHeadingStyle    = lipgloss.NewStyle().Bold(true)
+	docSubheadingStyle = lipgloss.NewStyle().Bold(true)
+	docCodeStyle       = lipgloss.NewStyle()
+	docCodeSpan        = regexp.MustCompile("`([^`]+)`")
+)
+
+// docPage is a rendered documentation page.
+type docPage struct {
+	title   string
+	content string
+}
+
+// docs is the embedded documentation browser.
+type docs struct {
+	pages    []docPage
+	current  int
+	viewport viewport.Model
+}
+
+// newDocs loads and renders the bundled documentation.
+func newDocs() docs {
+	var pages []docPage
+	files, _ := fs.Glob(docsFS, "docs/*.md")
+	for _, f := range files {
+		src, err := docsFS.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		pages = append(pages, renderMarkdown(string(src)))
+	}
+	d := docs{pages: pages, viewport: viewport.New(maxWidth, listHeight)}
+	return d.show(0)
+}
+
+// renderMarkdown renders the small subset of Markdown used by the bundled
+// documentation: headings, lists, code spans and indented code blocks.
+func renderMarkdown(src string) docPage {
+	var page docPage
+	var b strings.Builder
+	for _, line := range strings.Split(src, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# "):
+			page.title = strings.TrimPrefix(line, "# ")
+			b.WriteString(docHeadingStyle.Render(strings.ToUpper(page.title)))
+		case strings.HasPrefix(line, "## "):
+			b.WriteString(docSubheadingStyle.Render(strings.TrimPrefix(line, "## ")))
+		case strings.HasPrefix(line, "    "):
+			b.WriteString(docCodeStyle.Render(line))
+		default:
+			b.WriteString(docCodeSpan.ReplaceAllStringFunc(line, func(s string) string {
+				return docCodeStyle.Render(strings.Trim(s, "`"))
+			}))
+		}
+		b.WriteString("\n")
+	}
+	page.content = b.String()
+	return page
+}
+
+// show displays the page at index i, wrapping around.
+func (d docs) show(i int) docs {
+	if len(d.pages) == 0 {
+		return d
+	}
+	d.current = (i + len(d.pages)) % len(d.pages)
+	d.viewport.SetContent(d.pages[d.current].content)
+	d.viewport.GotoTop()
+	return d
+}
+
+// SetSize resizes the pager to the available space.
+func (d docs) SetSize(width, height int) docs {
+	d.viewport.Width = width
+	d.viewport.Height = height - 2
+	return d
+}
+
+// Update handles the pager navigation.
+func (d docs) Update(msg tea.KeyMsg) (docs, tea.Cmd) {
+	switch msg.String() {
+	case "tab":
+		return d.show(d.current + 1), nil
+	case "shift+tab":
+		return d.show(d.current - 1), nil
+	}
+	var cmd tea.Cmd
+	d.viewport, cmd = d.viewport.Update(msg)
+	return d, cmd
+}
+
+// View renders the current page with a navigation footer.
+func (d docs) View() string {
+	if len(d.pages) == 0 {
+		return helperStyle.Render("No documentation available")
+	}
+	footer := fmt.Sprintf("%d/%d %s • %3.f%% • tab next page • esc back", d.current+1, len(d.pages), d.pages[d.current].title, d.viewport.ScrollPercent()*100)
+	return d.viewport.View() + "\n" + helperStyle.Render(footer)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// editHeader introduces the entries in the file opened in the editor.
+const editHeader = `# Entries of %s, one per line: the time, then optionally the project between
+# brackets, $ when the span it opens is billable, and a note:
+#   08:00 [acme] $ standup
+# Lines starting with # are ignored. Delete every entry to clear the day, leave
+# the file unchanged to cancel.
+`
+
+// runEdit implements the edit command and returns the process exit code.
+func runEdit(args []string) int {
+	fs := flag.NewFlagSet("edit", flag.ExitOnError)
+	date := fs.String("date", "today", "day edited: today, yesterday or YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely edit [flags]")
+		fmt.Fprintln(fs.Output(), "Opens the stored entries of a day in $VISUAL or $EDITOR and stores them back once valid.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	day, err := timeutils.ParseDate(*date, homeNow())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the entries:", err)
+		return 1
+	}
+	stored, err := store.Load(day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the entries:", err)
+		return 1
+	}
+
+	f, err := os.CreateTemp("", "timely-*.txt")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot edit the entries:", err)
+		return 1
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	original := fmt.Sprintf(editHeader, day.Format("2006-01-02")) + stored.Entries.Text()
+	text := original
+	for {
+		if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot edit the entries:", err)
+			return 1
+		}
+		if err := openEditor(path); err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot run the editor:", err)
+			return 1
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot edit the entries:", err)
+			return 1
+		}
+		if string(b) == text {
+			if text != original {
+				fmt.Fprintln(os.Stderr, "Nothing stored, the entries are still invalid")
+				return 1
+			}
+			fmt.Println("Nothing changed")
+			return 0
+		}
+
+		entries, err := tracking.ParseText(string(b), day)
+		if err == nil {
+			stored.Entries = entries
+			if err := store.Save(day, stored); err != nil {
+				fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+				return 1
+			}
+			fmt.Println(day.Format("2006-01-02") + " " + daySummary(entries))
+			return 0
+		}
+		// The errors are reported at the top of the file, which is opened again
+		text = "# " + strings.ReplaceAll(err.Error(), "\n", "\n# ") + "\n" + withoutErrors(string(b))
+	}
+}
+
+// withoutErrors removes the errors reported at the top of an edited file, they are
+// the lines before the header.
+func withoutErrors(text string) string {
+	if i := strings.Index(text, "# Entries of "); i > 0 {
+		return text[i:]
+	}
+	return text
+}
+
+// openEditor opens path in the editor of the user, $VISUAL or $EDITOR, and waits
+// until it is closed.
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	// The editor may come with arguments, e.g. "code --wait"
+	words := strings.Fields(editor)
+	cmd := exec.Command(words[0], append(words[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,542 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// exportFormats lists the formats of the export command.
+var exportFormats = []string{"csv", "json", "ics", "html-timeline", "timeclock"}
+
+// exportedDay holds the stored entries of a day, paired into spans.
+type exportedDay struct {
+	Date          string            `json:"date"`
+	TargetSeconds int64             `json:"target_seconds,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Spans         []exportedSpan    `json:"spans"`
+}
+
+// exportedSpan is a worked span, End is nil while the span is open.
+type exportedSpan struct {
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end,omitempty"`
+	Project  string     `json:"project,omitempty"`
+	Billable bool       `json:"billable,omitempty"`
+	Notes    []string   `json:"notes,omitempty"`
+	// entries are the stored entries of the span, for the formats keeping them apart
+	entries tracking.Entries
+}
+
+// Duration returns the length of a closed span, zero for an open one.
+func (s exportedSpan) Duration() time.Duration {
+	if s.End == nil {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+// runExport implements the export command and returns the process exit code.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "csv", "output format: "+strings.Join(exportFormats, ", "))
+	from := fs.String("from", "", "first day exported: today, yesterday or YYYY-MM-DD, the first day stored by default")
+	to := fs.String("to", "", "last day exported, the last day stored by default")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely export [flags]")
+		fmt.Fprintln(fs.Output(), "Prints the stored entries: a line per span in CSV, the days and their spans in JSON,")
+		fmt.Fprintln(fs.Output(), "an event per worked span in iCalendar, a page drawing the days in HTML, or the clock-ins")
+		fmt.Fprintln(fs.Output(), "and clock-outs of hledger and ledger.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	now := homeNow()
+	var start, end time.Time
+	var err error
+	if *from != "" {
+		if start, err = timeutils.ParseDate(*from, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if *to != "" {
+		if end, err = timeutils.ParseDate(*to, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+	days, err := exportDays(start, end)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the stored entries:", err)
+		return 1
+	}
+
+	switch *format {
+	case "csv":
+		err = writeExportCSV(os.Stdout, days)
+	case "json":
+		err = json.NewEncoder(os.Stdout).Encode(days)
+	case "ics":
+		err = writeICS(os.Stdout, days, now)
+	case "html-timeline":
+		err = writeHTMLTimeline(os.Stdout, days, now)
+	case "timeclock":
+		err = writeTimeclock(os.Stdout, days)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q, expected one of %s\n", *format, strings.Join(exportFormats, ", "))
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// exportDays returns the stored days from start until end, a zero time leaving the
+// range open on that side. Days without entries are left out.
+func exportDays(start, end time.Time) ([]exportedDay, error) {
+	store, err := dayStore()
+	if err != nil {
+		return nil, err
+	}
+	dates, err := store.Days()
+	if err != nil {
+		return nil, err
+	}
+
+	days := []exportedDay{}
+	for _, date := range dates {
+		if (!start.IsZero() && date.Before(start)) || (!end.IsZero() && !date.Before(end)) {
+			continue
+		}
+		stored, err := store.Load(date)
+		if err != nil {
+			return nil, err
+		}
+		if len(stored.Entries) == 0 {
+			continue
+		}
+		days = append(days, exportDay(date, stored))
+	}
+	return days, nil
+}
+
+// exportDay converts what is stored of the day of date.
+func exportDay(date time.Time, stored tracking.Day) exportedDay {
+	day := exportedDay{Date: date.Format("2006-01-02"), TargetSeconds: int64(stored.Target.Seconds()), Metadata: stored.Metadata}
+	for _, span := range pairs(stored.Entries) {
+		day.Spans = append(day.Spans, exportSpan(span))
+	}
+	return day
+}
+
+// exportSpan converts a span of one or two entries. The project and the billable
+// flag are the ones of its start, the notes are the ones of both entries.
+func exportSpan(span tracking.Entries) exportedSpan {
+	s := exportedSpan{Start: span[0].Time, Project: span[0].Project, Billable: span[0].Billable, entries: span}
+	if len(span) > 1 {
+		s.End = &span[1].Time
+	}
+	for _, e := range span {
+		if e.Note != "" {
+			s.Notes = append(s.Notes, e.Note)
+		}
+	}
+	return s
+}
+
+// writeExportCSV writes a line per span, open spans without end nor duration.
+func writeExportCSV(out io.Writer, days []exportedDay) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"date", "start", "end", "duration", "project", "billable", "notes"})
+	for _, day := range days {
+		for _, s := range day.Spans {
+			end, duration := "", ""
+			if s.End != nil {
+				end, duration = timeutils.FormatTime(*s.End), timeutils.FormatDuration(s.Duration())
+			}
+			_ = w.Write([]string{day.Date, timeutils.FormatTime(s.Start), end, duration, s.Project,
+				strconv.FormatBool(s.Billable), strings.Join(s.Notes, "; ")})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// icsTime is the layout of the UTC times of iCalendar.
+const icsTime = "20060102T150405Z"
+
+// writeICS writes an iCalendar with an event per worked span. Open spans are left
+// out as an event needs an end.
+func writeICS(out io.Writer, days []exportedDay, now time.Time) error {
+	var b strings.Builder
+	line := func(name, value string) {
+		b.WriteString(icsFold(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//fredjeck//timely//EN")
+	for _, day := range days {
+		for _, s := range day.Spans {
+			if s.End == nil {
+				continue
+			}
+			summary := "Work"
+			if s.Project != "" {
+				summary += " [" + s.Project + "]"
+			}
+			line("BEGIN", "VEVENT")
+			line("UID", s.Start.UTC().Format(icsTime)+"@timely")
+			line("DTSTAMP", now.UTC().Format(icsTime))
+			line("DTSTART", s.Start.UTC().Format(icsTime))
+			line("DTEND", s.End.UTC().Format(icsTime))
+			line("SUMMARY", icsEscape(summary))
+			if len(s.Notes) > 0 {
+				line("DESCRIPTION", icsEscape(strings.Join(s.Notes, "\n")))
+			}
+			line("END", "VEVENT")
+		}
+	}
+	line("END", "VCALENDAR")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// icsEscape escapes a text value of iCalendar.
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icsFold folds a content line longer than 75 octets, continuation lines start with
+// a space. Lines are only folded between runes.
+func icsFold(s string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
+
+// htmlPalette colors the spans of each project in the HTML timeline, in the order
+// the projects first appear.
+var htmlPalette = []string{"#7d56f4", "#2a9d8f", "#e76f51", "#e9c46a", "#457b9d", "#d62872"}
+
+// htmlTimeline is what the template of the HTML timeline renders.
+type htmlTimeline struct {
+	Title    string
+	Hours    []htmlTick
+	Lanes    []htmlLane
+	Projects []htmlProject
+}
+
+// htmlTick is an hour of the axis, Left being its position in percent.
+type htmlTick struct {
+	Left  float64
+	Label string
+}
+
+// htmlLane is the lane of a day.
+type htmlLane struct {
+	Date, Total, Details string
+	Blocks               []htmlBlock
+}
+
+// htmlBlock is a span or a break within a lane, positioned in percent of the axis.
+type htmlBlock struct {
+	Left, Width float64
+	Class       string // span, open or break
+	Color       string
+	Label       string
+	Title       string
+}
+
+// htmlProject is an entry of the legend.
+type htmlProject struct {
+	Name, Color string
+}
+
+// writeHTMLTimeline writes a single HTML page showing a lane per day, with its spans
+// and the breaks between them on a common axis of hours. The page needs nothing but
+// a browser: its style and its script, zooming on the lanes, are inline. Open spans
+// run until now on the day of now, and are a mere mark on the other days.
+func writeHTMLTimeline(out io.Writer, days []exportedDay, now time.Time) error {
+	page := htmlTimeline{Title: "Timely"}
+	if len(days) > 0 {
+		page.Title += " " + days[0].Date
+		if last := days[len(days)-1].Date; last != days[0].Date {
+			page.Title += " – " + last
+		}
+	}
+
+	// The axis goes from the hour of the earliest start to the one of the latest end
+	first, last := 24*time.Hour, time.Duration(0)
+	for _, day := range days {
+		for _, s := range day.Spans {
+			end := htmlSpanEnd(s, now)
+			first, last = min(first, clockTime(s.Start)), max(last, clockTime(end))
+		}
+	}
+	first = first.Truncate(time.Hour)
+	last = min((last + time.Hour - 1).Truncate(time.Hour), 24*time.Hour)
+	if last <= first {
+		first, last = 0, 24*time.Hour
+	}
+	at := func(t time.Time) float64 {
+		return float64(clockTime(t)-first) * 100 / float64(last-first)
+	}
+	for h := first; h <= last; h += time.Hour {
+		page.Hours = append(page.Hours, htmlTick{Left: float64(h-first) * 100 / float64(last-first), Label: fmt.Sprintf("%02d", int(h.Hours()))})
+	}
+
+	colors := make(map[string]string)
+	for _, day := range days {
+		date, _ := time.ParseInLocation("2006-01-02", day.Date, now.Location())
+		lane := htmlLane{Date: date.Format("Mon 2006-01-02")}
+		var total time.Duration
+		var previous time.Time
+		for _, s := range day.Spans {
+			if !previous.IsZero() && s.Start.After(previous) {
+				lane.Blocks = append(lane.Blocks, htmlBlock{Left: at(previous), Width: at(s.Start) - at(previous), Class: "break",
+					Title: "break " + timeutils.FormatTime(previous) + "-" + timeutils.FormatTime(s.Start) +
+						" (" + timeutils.FormatDuration(s.Start.Sub(previous)) + ")"})
+			}
+			end := htmlSpanEnd(s, now)
+			total += end.Sub(s.Start)
+			block := htmlBlock{Left: at(s.Start), Width: at(end) - at(s.Start), Class: "span", Label: s.Project,
+				Title: spanText(s.entries) + " (" + timeutils.FormatDuration(end.Sub(s.Start)) + ")"}
+			if s.End == nil {
+				block.Class = "open"
+			}
+			if s.Project != "" {
+				if _, ok := colors[s.Project]; !ok {
+					colors[s.Project] = htmlPalette[len(colors)%len(htmlPalette)]
+					page.Projects = append(page.Projects, htmlProject{Name: s.Project, Color: colors[s.Project]})
+				}
+				block.Color = colors[s.Project]
+			}
+			lane.Blocks = append(lane.Blocks, block)
+			previous = end
+		}
+		lane.Total = timeutils.FormatDuration(total)
+		if day.TargetSeconds > 0 {
+			lane.Total += " / " + timeutils.FormatDuration(time.Duration(day.TargetSeconds)*time.Second)
+		}
+		var details []string
+		for _, key := range slices.Sorted(maps.Keys(day.Metadata)) {
+			details = append(details, key+": "+day.Metadata[key])
+		}
+		lane.Details = strings.Join(details, ", ")
+		page.Lanes = append(page.Lanes, lane)
+	}
+	return htmlTimelineTemplate.Execute(out, page)
+}
+
+// htmlSpanEnd returns the end of a span in the HTML timeline: now for the open span
+// of the day of now, its start for the open spans of other days.
+func htmlSpanEnd(s exportedSpan, now time.Time) time.Time {
+	switch {
+	case s.End != nil:
+		return *s.End
+	case timeutils.SameDay(s.Start, now) && now.After(s.Start):
+		return now
+	}
+	return s.Start
+}
+
+// clockTime returns the time of day of t on the wall clock, whatever the changes of
+// daylight saving time.
+func clockTime(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// htmlTimelineTemplate renders the HTML timeline, see writeHTMLTimeline.
+var htmlTimelineTemplate = template.Must(template.New("timeline").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+:root { --zoom: 1; }
+body { font-family: system-ui, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.3em; }
+.controls { margin: 1em 0; display: flex; gap: .5em; align-items: center; }
+.legend span { display: inline-block; margin-right: 1em; }
+.legend i { display: inline-block; width: .8em; height: .8em; margin-right: .3em; border-radius: 2px; }
+.timeline { display: grid; grid-template-columns: max-content 1fr max-content; gap: .4em 1em; align-items: center; }
+.scroll { overflow-x: auto; }
+.track { position: relative; width: calc(var(--zoom) * 100%); min-width: 100%; height: 1.6em; background: #f4f4f6; border-radius: 3px; }
+.axis .track { background: none; height: 1.2em; }
+.tick { position: absolute; top: 0; transform: translateX(-50%); font-size: .75em; color: #888; }
+.block { position: absolute; top: 0; bottom: 0; border-radius: 3px; overflow: hidden; white-space: nowrap; font-size: .75em; color: #fff; line-height: 2.1em; text-indent: .3em; }
+.span { background: #7d56f4; }
+.open { background: repeating-linear-gradient(45deg, #7d56f4, #7d56f4 4px, #a58ef7 4px, #a58ef7 8px); min-width: 3px; }
+.break { background: repeating-linear-gradient(45deg, #ddd, #ddd 3px, #f4f4f6 3px, #f4f4f6 6px); }
+.date { font-variant-numeric: tabular-nums; }
+.total { font-variant-numeric: tabular-nums; color: #555; }
+.details { color: #888; font-size: .8em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="controls">
+<button id="zoom-out" title="zoom out (-)">−</button>
+<input id="zoom" type="range" min="1" max="12" step="0.5" value="1" aria-label="zoom">
+<button id="zoom-in" title="zoom in (+)">+</button>
+<button id="zoom-reset" title="fit (0)">fit</button>
+</div>
+{{with .Projects}}<p class="legend">{{range .}}<span><i style="background: {{.Color}}"></i>{{.Name}}</span>{{end}}</p>{{end}}
+{{if .Lanes}}<div class="timeline">
+<div></div><div class="scroll axis"><div class="track">{{range .Hours}}<span class="tick" style="left: {{printf "%.3f" .Left}}%">{{.Label}}</span>{{end}}</div></div><div></div>
+{{range .Lanes}}<div class="date"{{with .Details}} title="{{.}}"{{end}}>{{.Date}}{{with .Details}}<div class="details">{{.}}</div>{{end}}</div>
+<div class="scroll"><div class="track">{{range .Blocks}}<div class="block {{.Class}}" style="left: {{printf "%.3f" .Left}}%; width: {{printf "%.3f" .Width}}%{{with .Color}}; background-color: {{.}}{{end}}" title="{{.Title}}">{{.Label}}</div>{{end}}</div></div>
+<div class="total">{{.Total}}</div>
+{{end}}</div>{{else}}<p>Nothing tracked.</p>{{end}}
+<script>
+(function () {
+  var zoom = document.getElementById("zoom");
+  var scrolls = document.querySelectorAll(".scroll");
+  function set(value) {
+    value = Math.min(Math.max(value, 1), 12);
+    zoom.value = value;
+    document.documentElement.style.setProperty("--zoom", value);
+  }
+  zoom.addEventListener("input", function () { set(Number(zoom.value)); });
+  document.getElementById("zoom-in").addEventListener("click", function () { set(Number(zoom.value) * 1.5); });
+  document.getElementById("zoom-out").addEventListener("click", function () { set(Number(zoom.value) / 1.5); });
+  document.getElementById("zoom-reset").addEventListener("click", function () { set(1); });
+  document.addEventListener("keydown", function (e) {
+    if (e.key === "+" || e.key === "=") set(Number(zoom.value) * 1.5);
+    if (e.key === "-") set(Number(zoom.value) / 1.5);
+    if (e.key === "0") set(1);
+  });
+  // The lanes scroll together, so that the hours stay aligned
+  scrolls.forEach(function (s) {
+    s.addEventListener("scroll", function () {
+      scrolls.forEach(function (o) { if (o !== s) o.scrollLeft = s.scrollLeft; });
+    });
+    s.addEventListener("wheel", function (e) {
+      if (!e.ctrlKey) return;
+      e.preventDefault();
+      set(Number(zoom.value) * (e.deltaY < 0 ? 1.25 : 0.8));
+    }, { passive: false });
+  });
+})();
+</script>
+</body>
+</html>
+`))
+
+const (
+	// timeclockTime is the layout of the dates and times of timeclock files.
+	timeclockTime = "2006/01/02 15:04:05"
+	// timeclockAccount is the account of the spans in timeclock files, their project
+	// being a subaccount: "timely:acme". A span without project is clocked in the
+	// account itself, which no project can be mistaken for.
+	timeclockAccount = "timely"
+)
+
+// writeTimeclock writes the clock-in and the clock-out of each span as hledger and
+// ledger read them, the project being a subaccount:
+//
+//	i 2025/03/14 08:00:00 timely:acme  standup  ; target:8h0m0s, billable:
+//	o 2025/03/14 12:00:00
+//
+// What the accounts and descriptions cannot hold is written as tags in a comment:
+// the target of the day on its first clock-in, the billable flag, and the project
+// and the billable flag of a clock-out when they differ from the ones of its clock-in.
+// An open span is a clock-in without clock-out. timely import --format timeclock
+// reads the file back.
+func writeTimeclock(out io.Writer, days []exportedDay) error {
+	var b strings.Builder
+	for _, day := range days {
+		for i, s := range day.Spans {
+			in := s.entries[0]
+			var tags []string
+			if i == 0 && day.TargetSeconds > 0 {
+				tags = append(tags, "target:"+(time.Duration(day.TargetSeconds)*time.Second).String())
+			}
+			if in.Billable {
+				tags = append(tags, "billable:")
+			}
+			line, err := timeclockLine("i", in, in.Project, tags)
+			if err != nil {
+				return err
+			}
+			b.WriteString(line)
+			if len(s.entries) == 1 {
+				continue
+			}
+
+			end := s.entries[1]
+			tags = nil
+			if end.Project != in.Project {
+				tags = append(tags, "project:"+end.Project)
+			}
+			if end.Billable != in.Billable {
+				tags = append(tags, "billable:"+strconv.FormatBool(end.Billable))
+			}
+			// The clock-out names the account clocked in, so that the session closed is
+			// unambiguous
+			if line, err = timeclockLine("o", end, in.Project, tags); err != nil {
+				return err
+			}
+			b.WriteString(line)
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// timeclockLine returns the entry of a timeclock file recording e, in the account of
+// project. The account is followed by two spaces before the note, the tags are in a
+// comment. Notes and projects which would be read back differently are an error.
+func timeclockLine(code string, e tracking.Entry, project string, tags []string) (string, error) {
+	at := e.Time.Format(timeclockTime)
+	if strings.Contains(e.Note, ";") {
+		return "", fmt.Errorf("%s: the note %q holds a ;, which starts a comment in timeclock files", at, e.Note)
+	}
+	for _, p := range []string{project, e.Project} {
+		if strings.ContainsAny(p, ";,") || strings.Contains(p, "  ") {
+			return "", fmt.Errorf("%s: the project %q holds a ; a , or two spaces, which timeclock files cannot keep", at, p)
+		}
+	}
+
+	line := code + " " + at
+	if code == "i" || e.Note != "" || len(tags) > 0 {
+		line += " " + timeclockAccount
+		if project != "" {
+			line += ":" + project
+		}
+	}
+	if e.Note != "" {
+		line += "  " + e.Note
+	}
+	if len(tags) > 0 {
+		line += "  ; " + strings.Join(tags, ", ")
+	}
+	return line + "\n", nil
+}
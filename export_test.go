@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestTimeclock_RoundTrip(t *testing.T) {
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	tests := []struct {
+		name string
+		day  tracking.Day
+	}{
+		{"spans", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0), Note: "standup", Project: "acme", Billable: true}, {Time: at(12, 0), Note: "lunch", Project: "acme", Billable: true},
+			{Time: at(13, 0)}, {Time: at(17, 0), Note: "done"},
+		}, Target: 8 * time.Hour}},
+		{"project named after the account", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0), Project: "timely"}, {Time: at(9, 0), Project: "timely"},
+			{Time: at(9, 0), Project: "timely:web"}, {Time: at(10, 0)},
+		}}},
+		{"project switch", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0), Project: "acme"}, {Time: at(10, 0), Project: "acme"},
+			{Time: at(10, 0), Project: "big client", Billable: true}, {Time: at(12, 0), Project: "other", Billable: false},
+		}}},
+		{"notes with spaces and colons", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0), Note: "call  follow-up: later, maybe"}, {Time: at(9, 0), Note: "a:b"},
+		}}},
+		{"open span", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0), Project: "acme"},
+		}, Target: 6*time.Hour + 43*time.Minute + 12*time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := writeTimeclock(&b, []exportedDay{exportDay(date, tt.day)}); err != nil {
+				t.Fatalf("writeTimeclock() error = %v", err)
+			}
+			spans, err := readTimeclock(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatalf("readTimeclock() error = %v\n%s", err, b.String())
+			}
+			got := tracking.Day{Entries: tracking.Entries{}}
+			for _, span := range spans {
+				if got, _, err = mergeSpan(got, span); err != nil {
+					t.Fatalf("mergeSpan() error = %v\n%s", err, b.String())
+				}
+			}
+			if !got.Entries.Equal(tt.day.Entries) || got.Target != tt.day.Target {
+				t.Errorf("round trip through\n%s= %v, target %s\nwant %v, target %s", b.String(), got.Entries, got.Target,
+					tt.day.Entries, tt.day.Target)
+			}
+		})
+	}
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	tests := []struct {
+		name string
+		day  tracking.Day
+	}{
+		{"spans", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0), Note: "standup", Project: "acme", Billable: true}, {Time: at(12, 0), Note: "lunch", Project: "acme", Billable: true},
+			{Time: at(13, 0)}, {Time: at(17, 0)},
+		}, Target: 8 * time.Hour}},
+		{"metadata", tracking.Day{Entries: tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0)}},
+			Metadata: map[string]string{"mood": "good", "ticket": "ABC-12"}}},
+		{"open span", tracking.Day{Entries: tracking.Entries{
+			{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0), Note: "review", Project: "acme"},
+		}, Target: 6 * time.Hour, Metadata: map[string]string{"mood": "tired"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal([]exportedDay{exportDay(date, tt.day)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			spans, err := readJSONSpans(bytes.NewReader(b))
+			if err != nil {
+				t.Fatalf("readJSONSpans() error = %v\n%s", err, b)
+			}
+			got := tracking.Day{Entries: tracking.Entries{}}
+			for _, span := range spans {
+				if got, _, err = mergeSpan(got, span); err != nil {
+					t.Fatalf("mergeSpan() error = %v\n%s", err, b)
+				}
+			}
+			if !got.Entries.Equal(tt.day.Entries) || got.Target != tt.day.Target || !maps.Equal(got.Metadata, tt.day.Metadata) {
+				t.Errorf("round trip through\n%s\n= %v, target %s, metadata %v\nwant %v, target %s, metadata %v", b, got.Entries, got.Target,
+					got.Metadata, tt.day.Entries, tt.day.Target, tt.day.Metadata)
+			}
+		})
+	}
+}
+
+func TestWriteTimeclock_Unrepresentable(t *testing.T) {
+	at := time.Date(2025, time.March, 14, 8, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		entries tracking.Entries
+		wantErr string
+	}{
+		{"semicolon in a note", tracking.Entries{{Time: at, Note: "call; follow-up"}}, "holds a ;"},
+		{"comma in a project", tracking.Entries{{Time: at, Project: "acme, inc"}}, "timeclock files cannot keep"},
+		{"two spaces in a clock-out project", tracking.Entries{{Time: at}, {Time: at.Add(time.Hour), Project: "big  client"}},
+			"timeclock files cannot keep"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			err := writeTimeclock(&b, []exportedDay{exportDay(at, tracking.Day{Entries: tt.entries})})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("writeTimeclock() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteHTMLTimeline(t *testing.T) {
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return date.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	day := tracking.Day{Entries: tracking.Entries{
+		{Time: at(8, 0), Project: "<acme>"}, {Time: at(12, 0), Project: "<acme>"}, {Time: at(12, 45)}, {Time: at(17, 0)},
+	}, Target: 8 * time.Hour}
+	open := tracking.Day{Entries: tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}}
+	tests := []struct {
+		name string
+		days []exportedDay
+		now  time.Time
+		want []string
+	}{
+		{"nothing tracked", nil, at(18, 0), []string{"<p>Nothing tracked.</p>"}},
+		{"spans and break", []exportedDay{exportDay(date, day)}, at(18, 0), []string{
+			`<span class="tick" style="left: 0.000%">08</span>`, `<span class="tick" style="left: 100.000%">17</span>`,
+			`<div class="block span" style="left: 0.000%; width: 44.444%; background-color: #7d56f4" title="08:00-12:00 [&lt;acme&gt;] (04:00)">&lt;acme&gt;</div>`,
+			`<div class="block break" style="left: 44.444%; width: 8.333%" title="break 12:00-12:45 (00:45)">`,
+			`<div class="total">08:15 / 08:00</div>`,
+		}},
+		{"open span of today", []exportedDay{exportDay(date, open)}, at(15, 0), []string{
+			`<div class="block open" style="left: 71.429%; width: 28.571%" title="13:00-… (02:00)">`, `<div class="total">06:00</div>`,
+		}},
+		{"open span of another day", []exportedDay{exportDay(date, open)}, at(15, 0).AddDate(0, 0, 1), []string{
+			`<div class="block open" style="left: 100.000%; width: 0.000%" title="13:00-… (00:00)">`, `<div class="total">04:00</div>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := writeHTMLTimeline(&b, tt.days, tt.now); err != nil {
+				t.Fatalf("writeHTMLTimeline() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("writeHTMLTimeline() wrote\n%s\nwant it to contain %s", b.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"short line", "SUMMARY:standup", "SUMMARY:standup"},
+		{"75 octets", strings.Repeat("a", 75), strings.Repeat("a", 75)},
+		{"76 octets", strings.Repeat("a", 76), strings.Repeat("a", 75) + "\r\n a"},
+		// Continuation lines hold 74 octets after their leading space
+		{"several folds", strings.Repeat("a", 75+74+1), strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a"},
+		// é takes two octets, the line is folded before the rune which would cross 75 octets
+		{"between runes", "a" + strings.Repeat("é", 40), "a" + strings.Repeat("é", 37) + "\r\n " + strings.Repeat("é", 3)},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := icsFold(tt.input); got != tt.want {
+				t.Errorf("icsFold(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
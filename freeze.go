@@ -0,0 +1,25 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+var pausedStyle = lipgloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1)
+
+// Freeze stops the screen on what it displays, e.g. during a screen share. The
+// tracking goes on in the background and shows up again once resumed.
+func (m model) Freeze() model {
+	m.frozenView = m.View()
+	m.frozen = true
+	return m
+}
+
+// Resume brings the screen back to life after Freeze.
+func (m model) Resume() model {
+	m.frozen = false
+	m.frozenView = ""
+	return m.RecalculateDurations()
+}
+
+// frozenScreen renders the screen captured by Freeze under a banner.
+func (m model) frozenScreen() string {
+	return pausedStyle.Render(tr("PAUSED")) + helperStyle.Render(" "+trf("%s to resume", m.keys.Freeze.Help().Key)) + "\n" + m.frozenView
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// headerGradient shifts the accent color of the header as the day progresses.
+type headerGradient struct {
+	start    colorful.Color
+	exit     colorful.Color
+	overtime colorful.Color
+}
+
+// parseHeaderGradient parses a comma separated list of three hex colors: start of the day, planned exit and overtime.
+// An empty string or "none" disables the gradient and returns nil.
+func parseHeaderGradient(s string) (*headerGradient, error) {
+	if s = strings.TrimSpace(s); s == "" || s == "none" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("expected three comma separated colors, got %q", s)
+	}
+	colors := make([]colorful.Color, len(parts))
+	for i, p := range parts {
+		c, err := colorful.Hex(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("invalid color %q: %w", p, err)
+		}
+		colors[i] = c
+	}
+	return &headerGradient{start: colors[0], exit: colors[1], overtime: colors[2]}, nil
+}
+
+// Color returns the accent color for the given progress towards the target, above 1 being overtime.
+func (g *headerGradient) Color(progress float64) lipgloss.Color {
+	if progress > 1 {
+		return lipgloss.Color(g.overtime.Hex())
+	}
+	t := math.Max(progress, 0)
+	return lipgloss.Color(g.start.BlendLuv(g.exit, t).Clamped().Hex())
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// Breakpoints of the header layout: from wideLayout columns the header fits on a
+// single line with full labels, below labels are shortened and metrics wrap over
+// several lines, and under narrowLayout columns metrics are stacked one per line.
+const (
+	wideLayout   = 90
+	narrowLayout = 60
+)
+
+const headerSeparator = " • "
+
+// headerMetric is a labelled figure of the header.
+type headerMetric struct {
+	label string
+	short string
+	value string
+}
+
+// headerMetrics lists the figures displayed after the total, in order. The date
+// and week come first, to give context to sessions running across midnight.
+func (m model) headerMetrics() []headerMetric {
+	if m.today != nil {
+		return m.pastMetrics()
+	}
+	accent := m.accentStyle()
+	now := m.clock()
+	week := accent.Render(fmt.Sprintf("W%02d", timeutils.WeekOf(now).Number))
+	if m.week.found && m.week.week == timeutils.WeekOf(now) {
+		week += " " + accent.Render(timeutils.FormatDuration(m.week.worked()+m.totalProvisionnal))
+	}
+	metrics := []headerMetric{
+		{"", "", helperStyle.Render(formatDate(now, "Mon 2 Jan")+" ") + week},
+		{tr("projected"), tr("proj"), accent.Render(timeutils.FormatDuration(m.totalProvisionnal))},
+		{tr("start"), tr("start"), accent.Render(timeutils.FormatTime(m.startupTime)) + m.startupSourceView()},
+		{tr("exit"), tr("exit"), accent.Render(m.planned) + m.plannedBreakView()},
+	}
+	if latest := m.latestExit(); latest != "" {
+		metrics = append(metrics, headerMetric{tr("leave by"), tr("by"), m.overtimeStyle().Render(latest)})
+	}
+	metrics = append(metrics, []headerMetric{
+		{tr("overtime"), tr("over"), m.overtimeStyle().Render(timeutils.FormatDuration(m.overtime))},
+	}...)
+	if m.balance.found {
+		metrics = append(metrics, headerMetric{tr("balance"), tr("bal"), accent.Render(timeutils.FormatDuration(m.balance.balance))})
+	}
+	if !m.bootTime.IsZero() {
+		metrics = append(metrics, headerMetric{tr("machine up"), tr("up"), accent.Render(timeutils.FormatDuration(time.Since(m.bootTime)))})
+	}
+	if m.project != "" {
+		metrics = append(metrics, headerMetric{tr("project"), tr("prj"), accent.Render(m.project)})
+	}
+	if m.billing() {
+		metrics = append(metrics, headerMetric{tr("billable"), tr("bill"), m.billableView()})
+	}
+	if !m.lunchReturn.IsZero() && len(m.entries)%2 == 0 {
+		metrics = append(metrics, headerMetric{tr("back at"), tr("back"), accent.Render(timeutils.FormatTime(m.lunchReturn))})
+	}
+	if m.pomodoro.Running() {
+		metrics = append(metrics, headerMetric{"🍅", "🍅", m.pomodoroView()})
+	}
+	// Flag that times are displayed in the home time zone while the system is set to another one
+	if m.home != nil && !timeutils.SameOffset(m.home, time.Local, now) {
+		before, after, _ := strings.Cut(tr("%s time"), "%s")
+		metrics = append(metrics, headerMetric{"✈", "✈", helperStyle.Render(before) + accent.Render(m.home.String()) + helperStyle.Render(after)})
+	}
+	return metrics
+}
+
+// headerView renders the state, the total and the header metrics. On narrow
+// terminals, labels are shortened and metrics wrap instead of overflowing.
+func (m model) headerView(total lipgloss.Style) string {
+	first := m.clockedView() + " " +
+		total.Render(timeutils.FormatDuration(m.total)) +
+		helperStyle.Render(" / "+timeutils.FormatDuration(m.target))
+	if !m.until.IsZero() {
+		first += helperStyle.Render(" "+tr("until")+" ") + m.accentStyle().Render(timeutils.FormatTime(m.until))
+	}
+
+	wide := m.width == 0 || m.width >= wideLayout
+	lines := []string{first}
+	for _, metric := range m.headerMetrics() {
+		label := metric.label
+		if !wide {
+			label = metric.short
+		}
+		part := metric.value
+		if label != "" {
+			part = helperStyle.Render(label+" ") + part
+		}
+
+		current := lines[len(lines)-1]
+		fits := m.width >= narrowLayout && lipgloss.Width(current)+lipgloss.Width(headerSeparator)+lipgloss.Width(part) <= m.width
+		if wide || fits {
+			lines[len(lines)-1] = current + helperStyle.Render(headerSeparator) + part
+		} else {
+			lines = append(lines, part)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// latestExit returns the time at which the maximum working time is reached, when
+// a maximum is set and entries were recorded.
+func (m model) latestExit() string {
+	last := m.durations.Last()
+	if m.limits.Cap <= 0 || last.IsZero() {
+		return ""
+	}
+	return timeutils.FormatTime(last.Add(m.limits.Cap - m.total))
+}
+
+// plannedBreakView renders the break time still to be taken included in the exit, if any.
+func (m model) plannedBreakView() string {
+	if m.plannedBreak <= 0 || len(m.durations) == 0 {
+		return ""
+	}
+	return helperStyle.Render(" " + trf("incl. %s break", timeutils.FormatDuration(m.plannedBreak)))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestLoadWeek(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	setFlags(t, map[string]string{"full-day": "8h", "work-week": "", "target": "8:00"})
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	span := func(day string, hours int) {
+		start, _ := time.ParseInLocation("2006-01-02 15:04", day+" 08:00", time.Local)
+		entries := tracking.Entries{{Time: start}, {Time: start.Add(time.Duration(hours) * time.Hour)}}
+		if err := store.Save(start, tracking.Day{Entries: entries}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	span("2025-03-09", 5) // Sunday of the week before
+	span("2025-03-10", 4)
+	span("2025-03-11", 3)
+	span("2025-03-14", 2) // today, counted by the tracker
+	now := time.Date(2025, time.March, 14, 16, 0, 0, 0, time.Local)
+
+	msg := loadWeek(now, now)()
+	if got, ok := msg.(weekLoaded); !ok || !got.found || got.worked() != 7*time.Hour || len(got.days) != 2 {
+		t.Fatalf("loadWeek() = %+v, want 07:00 worked over 2 days", msg)
+	}
+	// A past week is loaded whole
+	msg = loadWeek(now.AddDate(0, 0, -7), now)()
+	if got, ok := msg.(weekLoaded); !ok || !got.found || got.worked() != 5*time.Hour {
+		t.Fatalf("loadWeek() of the week before = %+v, want 05:00 worked", msg)
+	}
+
+	m := initialModel(8*time.Hour, 0, 0, nil)
+	day := time.Now()
+	m = m.SetEntries(tracking.Entries{{Time: day.Add(-3 * time.Hour)}, {Time: day.Add(-time.Hour)}})
+	current := weekLoaded{week: timeutils.WeekOf(day), days: []reportLine{{Worked: 7 * time.Hour}}, found: true}
+	updated, _ := m.Update(current)
+	if week := updated.(model).headerMetrics()[0].value; !strings.Contains(week, "09:00") {
+		t.Errorf("header shows %q, want the week-to-date total 09:00", week)
+	}
+	if week := m.headerMetrics()[0].value; strings.Contains(week, ":") {
+		t.Errorf("header shows %q before the week is loaded, want no total", week)
+	}
+	// The total of another week, e.g. loaded for the week view of a past day, is left out
+	updated, _ = m.Update(msg)
+	if week := updated.(model).headerMetrics()[0].value; strings.Contains(week, ":") {
+		t.Errorf("header shows %q with another week loaded, want no total", week)
+	}
+}
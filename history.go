@@ -0,0 +1,39 @@
+package main
+
+import "github.com/fredjeck/timely/pkg/tracking"
+
+// maxHistory bounds the number of operations which can be undone.
+const maxHistory = 100
+
+// record pushes the entries as they were before an operation onto the undo
+// stack. Any operation invalidates what could be redone.
+func (m model) record(before tracking.Entries) model {
+	m.undo = append(m.undo, before)
+	if len(m.undo) > maxHistory {
+		m.undo = m.undo[len(m.undo)-maxHistory:]
+	}
+	m.redo = nil
+	return m
+}
+
+// Undo reverts the last operation on the entries.
+func (m model) Undo() model {
+	if len(m.undo) == 0 {
+		return m
+	}
+	previous := m.undo[len(m.undo)-1]
+	m.undo = m.undo[:len(m.undo)-1]
+	m.redo = append(m.redo, m.entries)
+	return m.SetEntries(previous)
+}
+
+// Redo applies again the last undone operation.
+func (m model) Redo() model {
+	if len(m.redo) == 0 {
+		return m
+	}
+	next := m.redo[len(m.redo)-1]
+	m.redo = m.redo[:len(m.redo)-1]
+	m.undo = append(m.undo, m.entries)
+	return m.SetEntries(next)
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/holidays"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+const (
+	// holidaysFile is the name of the file, within the data directory, keeping the holidays.
+	holidaysFile = "holidays.json"
+	// holidaysTimeout bounds the download of a calendar of holidays.
+	holidaysTimeout = 30 * time.Second
+)
+
+// holidaysPath returns the file keeping the holidays.
+func holidaysPath() (string, error) {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, holidaysFile), nil
+}
+
+// storedHolidays returns the holidays kept in the data directory, read once. This is
+// best effort, timely holidays list reports a file which cannot be read.
+var storedHolidays = sync.OnceValue(func() holidays.Holidays {
+	path, err := holidaysPath()
+	if err != nil {
+		return nil
+	}
+	hs, _ := holidays.Load(path)
+	return hs
+})
+
+// holidayOn returns the holiday of the day of t, if any.
+func holidayOn(t time.Time) (holidays.Holiday, bool) {
+	return storedHolidays().On(t)
+}
+
+// holidayNotice tells that the day is a holiday, or a vacation day.
+func holidayNotice(h holidays.Holiday) string {
+	if h.Vacation {
+		return tr("today is a vacation day")
+	}
+	if h.Name == "" {
+		return tr("today is a holiday")
+	}
+	return trf("today is a holiday: %s", h.Name)
+}
+
+// runHolidays implements the holidays command and returns the process exit code.
+func runHolidays(args []string) int {
+	actions := map[string]func([]string) int{
+		"list":   listHolidays,
+		"add":    addHolidays,
+		"remove": removeHoliday,
+		"import": importHolidays,
+	}
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	run, ok := actions[action]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown action %q, expected list, add, remove or import\n", action)
+		return 2
+	}
+	return run(args)
+}
+
+// holidaysUsage returns the usage of an action of the holidays command.
+func holidaysUsage(fs *flag.FlagSet, usage, summary string) func() {
+	return func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely holidays "+usage)
+		fmt.Fprintln(fs.Output(), summary)
+		fmt.Fprintln(fs.Output(), "Actions: list, add, remove and import, run timely holidays <action> -h for their flags.")
+		fs.PrintDefaults()
+	}
+}
+
+// listHolidays prints the holidays of a year.
+func listHolidays(args []string) int {
+	fs := flag.NewFlagSet("holidays list", flag.ExitOnError)
+	year := fs.Int("year", homeNow().Year(), "year listed")
+	all := fs.Bool("all", false, "list the holidays of every year")
+	fs.Usage = holidaysUsage(fs, "[list] [flags]", "Lists the public holidays and vacation days, which have no target.")
+	fs.Parse(args)
+
+	path, err := holidaysPath()
+	if err == nil {
+		var hs holidays.Holidays
+		if hs, err = holidays.Load(path); err == nil {
+			for _, h := range hs {
+				if !*all && h.Day().Year() != *year {
+					continue
+				}
+				kind := "holiday"
+				if h.Vacation {
+					kind = "vacation"
+				}
+				fmt.Println(strings.TrimSpace(fmt.Sprintf("%s  %-8s  %s", h.Day().Format("Mon 2006-01-02"), kind, h.Name)))
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the holidays:", err)
+		return 1
+	}
+	return 0
+}
+
+// addHolidays adds a holiday, or a range of vacation days.
+func addHolidays(args []string) int {
+	fs := flag.NewFlagSet("holidays add", flag.ExitOnError)
+	vacation := fs.Bool("vacation", false, "vacation days rather than a public holiday")
+	to := fs.String("to", "", "last day added, to add several days at once")
+	fs.Usage = holidaysUsage(fs, "add [flags] DAY [name]", "Adds a day off: today, yesterday, tomorrow or YYYY-MM-DD.")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	now := homeNow()
+	first, err := parseHolidayDate(fs.Arg(0), now)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	last := first
+	if *to != "" {
+		if last, err = parseHolidayDate(*to, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	if last.Before(first) {
+		fmt.Fprintf(os.Stderr, "--to %s is before %s\n", *to, fs.Arg(0))
+		return 2
+	}
+
+	name := strings.Join(fs.Args()[1:], " ")
+	var added holidays.Holidays
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		added = append(added, holidays.New(day, name, *vacation))
+	}
+	return saveHolidays(func(hs holidays.Holidays) holidays.Holidays { return hs.Add(added...) }, len(added))
+}
+
+// removeHoliday removes the holiday of a day.
+func removeHoliday(args []string) int {
+	fs := flag.NewFlagSet("holidays remove", flag.ExitOnError)
+	fs.Usage = holidaysUsage(fs, "remove DAY", "Removes the holiday or the vacation day of a day, which is worked again.")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	day, err := parseHolidayDate(fs.Arg(0), homeNow())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return saveHolidays(func(hs holidays.Holidays) holidays.Holidays { return hs.Remove(day) }, 1)
+}
+
+// importHolidays adds the holidays of a country preset, or the events of a calendar.
+func importHolidays(args []string) int {
+	fs := flag.NewFlagSet("holidays import", flag.ExitOnError)
+	country := fs.String("country", "", "country whose public holidays are added: "+strings.Join(holidays.Countries(), ", "))
+	year := fs.Int("year", homeNow().Year(), "year of the holidays of --country")
+	vacation := fs.Bool("vacation", false, "the events of the calendar are vacation days rather than public holidays")
+	fs.Usage = holidaysUsage(fs, "import --country CODE [--year YYYY] | import [--vacation] URL|file",
+		"Adds the public holidays of a country, or the events of an iCalendar file or URL.")
+	fs.Parse(args)
+
+	var hs holidays.Holidays
+	var err error
+	switch {
+	case *country != "" && fs.NArg() == 0:
+		hs, err = holidays.Preset(*country, *year)
+	case *country == "" && fs.NArg() == 1:
+		hs, err = readCalendar(fs.Arg(0), *vacation)
+	default:
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot import the holidays:", err)
+		return 1
+	}
+	return saveHolidays(func(stored holidays.Holidays) holidays.Holidays { return stored.Add(hs...) }, len(hs))
+}
+
+// readCalendar reads the events of the iCalendar file at source, a path or an URL.
+func readCalendar(source string, vacation bool) (holidays.Holidays, error) {
+	var r io.Reader
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := http.Client{Timeout: holidaysTimeout}
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: %s", source, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return holidays.ParseICS(r, vacation)
+}
+
+// saveHolidays changes the stored holidays with change, n days being changed, and
+// returns the process exit code.
+func saveHolidays(change func(holidays.Holidays) holidays.Holidays, n int) int {
+	path, err := holidaysPath()
+	if err == nil {
+		var hs holidays.Holidays
+		if hs, err = holidays.Load(path); err == nil {
+			err = holidays.Save(path, change(hs))
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the holidays:", err)
+		return 1
+	}
+	days := "days"
+	if n == 1 {
+		days = "day"
+	}
+	fmt.Println("Holidays updated, " + strconv.Itoa(n) + " " + days + " changed")
+	return 0
+}
+
+// parseHolidayDate parses a day as timeutils.ParseDate does, tomorrow included as
+// days off are mostly planned ahead.
+func parseHolidayDate(s string, now time.Time) (time.Time, error) {
+	if strings.EqualFold(s, "tomorrow") {
+		return timeutils.StartOfDay(now).AddDate(0, 0, 1), nil
+	}
+	return timeutils.ParseDate(s, now)
+}
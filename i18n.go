@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// language is the language of the user interface, see selectLanguage.
+var language = "en"
+
+// languages lists the languages of the user interface, English first.
+var languages = []string{"en", "fr", "de"}
+
+// catalogs translates the English strings of the user interface. Strings missing
+// from a catalog are displayed in English.
+var catalogs = map[string]map[string]string{
+	"fr": {
+		"projected":                       "prévu",
+		"proj":                            "prév",
+		"start":                           "début",
+		"exit":                            "sortie",
+		"leave by":                        "au plus tard",
+		"by":                              "max",
+		"overtime":                        "heures sup.",
+		"over":                            "sup",
+		"balance":                         "solde",
+		"bal":                             "sol",
+		"machine up":                      "machine allumée",
+		"up":                              "allumée",
+		"project":                         "projet",
+		"prj":                             "prj",
+		"billable":                        "facturable",
+		"bill":                            "fact",
+		"non-billable":                    "non facturable",
+		"back at":                         "retour à",
+		"back":                            "retour",
+		"until":                           "jusqu'à",
+		"Enjoy your day !":                "Bonne journée !",
+		"What is your target for today?":  "Quel est votre objectif aujourd'hui ?",
+		"enter to start • %s to quit":     "entrée pour commencer • %s pour quitter",
+		"Daily target reached":            "Objectif du jour atteint",
+		"You worked %s, enjoy your day !": "Vous avez travaillé %s, bonne journée !",
+		"summary copied to the clipboard": "résumé copié dans le presse-papiers",
+		"copy failed: %s":                 "échec de la copie : %s",
+		"overtime, target reached":        "heures sup., objectif atteint",
+		"remaining — leave at ":           "restant — sortie à ",
+		"remaining — on a break, clock in to resume": "restant — en pause, pointez pour reprendre",
+		"%s without a break, time for a pause?":      "%s sans pause, il est temps de souffler ?",
+		"%s to dismiss":                              "%s pour masquer",
+		"Time for a break":                           "C'est l'heure d'une pause",
+		"You worked %s without a break":              "Vous avez travaillé %s sans pause",
+		"clocked in":                                 "pointé",
+		"clocked out":                                "dépointé",
+		"worked %s of %s":                            "travaillé %s sur %s",
+		"selected":                                   "sélectionné",
+		"PAUSED":                                     "EN PAUSE",
+		"%s to resume":                               "%s pour reprendre",
+		"today is a day off in the work week":        "aujourd'hui est un jour de congé de la semaine de travail",
+		"today is a vacation day":                    "aujourd'hui est un jour de vacances",
+		"today is a holiday":                         "aujourd'hui est un jour férié",
+		"today is a holiday: %s":                     "aujourd'hui est un jour férié : %s",
+		"editing %s, enter the new value or esc":     "modification de %s, entrez la nouvelle valeur ou esc",
+		"past day: %s":                               "jour passé : %s",
+		"%s/%s other days • %s back to today":        "%s/%s autres jours • %s retour à aujourd'hui",
+		"only today can be clocked":                  "seul aujourd'hui peut être pointé",
+		"incl. %s break":                             "dont %s de pause",
+		"summary sent to the terminal, the clipboard tool failed: %s": "résumé envoyé au terminal, l'outil de presse-papiers a échoué : %s",
+		"Overtime warning":                  "Alerte heures sup.",
+		"Overtime":                          "Heures sup.",
+		"You are %s past your daily target": "Vous avez dépassé votre objectif du jour de %s",
+		"Maximum working time reached":      "Temps de travail maximal atteint",
+		"You worked %s, the maximum is %s":  "Vous avez travaillé %s, le maximum est %s",
+		"Break over, back to work":          "Fin de la pause, au travail",
+		"Pomodoro %d done, take a %s break": "Pomodoro %d terminé, faites une pause de %s",
+		"project switch":                    "changement de projet",
+		"break":                             "pause",
+		"work":                              "travail",
+		"still owed":                        "encore à prendre",
+		"%s time":                           "heure %s",
+		"other":                             "autres",
+		"add typed time":                    "ajouter l'heure saisie",
+		"type":                              "saisir",
+		"clock now":                         "pointer maintenant",
+		"edit":                              "modifier",
+		"annotate":                          "annoter",
+		"cancel/back":                       "annuler/retour",
+		"delete":                            "supprimer",
+		"undo":                              "défaire",
+		"redo":                              "refaire",
+		"move up":                           "monter",
+		"move down":                         "descendre",
+		"first":                             "premier",
+		"last":                              "dernier",
+		"previous day":                      "jour précédent",
+		"next day":                          "jour suivant",
+		"copy summary":                      "copier le résumé",
+		"copy detailed summary":             "copier le résumé détaillé",
+		"pomodoro":                          "pomodoro",
+		"compact mode":                      "mode compact",
+		"lunch break":                       "pause déjeuner",
+		"switch project":                    "changer de projet",
+		"toggle billable":                   "facturable ou non",
+		"change target":                     "changer l'objectif",
+		"countdown":                         "compte à rebours",
+		"freeze screen":                     "figer l'écran",
+		"docs":                              "documentation",
+		"help":                              "aide",
+		"quit":                              "quitter",
+		"force quit":                        "quitter de force",
+		"next view":                         "vue suivante",
+		"WEEK":                              "SEMAINE",
+		"loading…":                          "chargement…",
+		"total":                             "total",
+		"previous day in the calendar":      "jour précédent du calendrier",
+		"next day in the calendar":          "jour suivant du calendrier",
+		"not tracked":                       "non suivi",
+		"under half":                        "moins de la moitié",
+		"under the target":                  "sous l'objectif",
+		"target reached":                    "objectif atteint",
+		"move":                              "déplacer",
+		"show the day":                      "afficher le jour",
+		"STATS OF THE LAST %d WEEKS":        "STATISTIQUES DES %d DERNIÈRES SEMAINES",
+		"no day tracked before today":       "aucun jour suivi avant aujourd'hui",
+		"average arrival":                   "arrivée moyenne",
+		"average day length":                "durée moyenne du jour",
+		"breaks":                            "pauses",
+		"%s, %.1f a day":                    "%s, %.1f par jour",
+		"pomodoros":                         "pomodoros",
+		"%d today, %.1f a day":              "%d aujourd'hui, %.1f par jour",
+		"█ an hour or a pomodoro, over %d days": "█ une heure ou un pomodoro, sur %d jours",
+		"OVERTIME BY WEEK":                      "HEURES SUP. PAR SEMAINE",
+	},
+	"de": {
+		"projected":                       "voraussichtlich",
+		"proj":                            "vor.",
+		"start":                           "Beginn",
+		"exit":                            "Feierabend",
+		"leave by":                        "spätestens",
+		"by":                              "spät.",
+		"overtime":                        "Überstunden",
+		"over":                            "Üst.",
+		"balance":                         "Saldo",
+		"bal":                             "Sal.",
+		"machine up":                      "Laufzeit",
+		"up":                              "Lauf",
+		"project":                         "Projekt",
+		"prj":                             "Prj",
+		"billable":                        "abrechenbar",
+		"bill":                            "abr.",
+		"non-billable":                    "nicht abrechenbar",
+		"back at":                         "zurück um",
+		"back":                            "zurück",
+		"until":                           "bis",
+		"Enjoy your day !":                "Schönen Tag noch!",
+		"What is your target for today?":  "Wie lautet Ihr heutiges Ziel?",
+		"enter to start • %s to quit":     "Enter zum Starten • %s zum Beenden",
+		"Daily target reached":            "Tagesziel erreicht",
+		"You worked %s, enjoy your day !": "Sie haben %s gearbeitet, schönen Tag noch!",
+		"summary copied to the clipboard": "Zusammenfassung in die Zwischenablage kopiert",
+		"copy failed: %s":                 "Kopieren fehlgeschlagen: %s",
+		"overtime, target reached":        "Überstunden, Ziel erreicht",
+		"remaining — leave at ":           "verbleibend — Feierabend um ",
+		"remaining — on a break, clock in to resume": "verbleibend — in der Pause, zum Fortfahren einstempeln",
+		"%s without a break, time for a pause?":      "%s ohne Pause, Zeit für eine Pause?",
+		"%s to dismiss":                              "%s zum Ausblenden",
+		"Time for a break":                           "Zeit für eine Pause",
+		"You worked %s without a break":              "Sie haben %s ohne Pause gearbeitet",
+		"clocked in":                                 "eingestempelt",
+		"clocked out":                                "ausgestempelt",
+		"worked %s of %s":                            "%s von %s gearbeitet",
+		"selected":                                   "ausgewählt",
+		"PAUSED":                                     "ANGEHALTEN",
+		"%s to resume":                               "%s zum Fortsetzen",
+		"today is a day off in the work week":        "heute ist ein freier Tag der Arbeitswoche",
+		"today is a vacation day":                    "heute ist ein Urlaubstag",
+		"today is a holiday":                         "heute ist ein Feiertag",
+		"today is a holiday: %s":                     "heute ist ein Feiertag: %s",
+		"editing %s, enter the new value or esc":     "%s bearbeiten, neuen Wert eingeben oder esc",
+		"past day: %s":                               "vergangener Tag: %s",
+		"%s/%s other days • %s back to today":        "%s/%s andere Tage • %s zurück zu heute",
+		"only today can be clocked":                  "nur heute kann gestempelt werden",
+		"incl. %s break":                             "inkl. %s Pause",
+		"summary sent to the terminal, the clipboard tool failed: %s": "Zusammenfassung an das Terminal gesendet, das Zwischenablage-Tool schlug fehl: %s",
+		"Overtime warning":                  "Überstundenwarnung",
+		"Overtime":                          "Überstunden",
+		"You are %s past your daily target": "Sie sind %s über Ihrem Tagesziel",
+		"Maximum working time reached":      "Maximale Arbeitszeit erreicht",
+		"You worked %s, the maximum is %s":  "Sie haben %s gearbeitet, das Maximum ist %s",
+		"Break over, back to work":          "Pause vorbei, zurück an die Arbeit",
+		"Pomodoro %d done, take a %s break": "Pomodoro %d erledigt, machen Sie %s Pause",
+		"project switch":                    "Projektwechsel",
+		"break":                             "Pause",
+		"work":                              "Arbeit",
+		"still owed":                        "noch offen",
+		"%s time":                           "%s Zeit",
+		"other":                             "andere",
+		"add typed time":                    "eingegebene Zeit hinzufügen",
+		"type":                              "eingeben",
+		"clock now":                         "jetzt stempeln",
+		"edit":                              "bearbeiten",
+		"annotate":                          "notieren",
+		"cancel/back":                       "abbrechen/zurück",
+		"delete":                            "löschen",
+		"undo":                              "rückgängig",
+		"redo":                              "wiederholen",
+		"move up":                           "nach oben",
+		"move down":                         "nach unten",
+		"first":                             "erster",
+		"last":                              "letzter",
+		"previous day":                      "vorheriger Tag",
+		"next day":                          "nächster Tag",
+		"copy summary":                      "Zusammenfassung kopieren",
+		"copy detailed summary":             "ausführliche Zusammenfassung kopieren",
+		"pomodoro":                          "Pomodoro",
+		"compact mode":                      "Kompaktmodus",
+		"lunch break":                       "Mittagspause",
+		"switch project":                    "Projekt wechseln",
+		"toggle billable":                   "abrechenbar umschalten",
+		"change target":                     "Ziel ändern",
+		"countdown":                         "Countdown",
+		"freeze screen":                     "Bildschirm einfrieren",
+		"docs":                              "Doku",
+		"help":                              "Hilfe",
+		"quit":                              "beenden",
+		"force quit":                        "sofort beenden",
+		"next view":                         "nächste Ansicht",
+		"WEEK":                              "WOCHE",
+		"loading…":                          "wird geladen…",
+		"total":                             "Summe",
+		"previous day in the calendar":      "vorheriger Tag im Kalender",
+		"next day in the calendar":          "nächster Tag im Kalender",
+		"not tracked":                       "nicht erfasst",
+		"under half":                        "unter der Hälfte",
+		"under the target":                  "unter dem Ziel",
+		"target reached":                    "Ziel erreicht",
+		"move":                              "bewegen",
+		"show the day":                      "Tag anzeigen",
+		"STATS OF THE LAST %d WEEKS":        "STATISTIK DER LETZTEN %d WOCHEN",
+		"no day tracked before today":       "kein Tag vor heute erfasst",
+		"average arrival":                   "durchschnittliche Ankunft",
+		"average day length":                "durchschnittliche Tageslänge",
+		"breaks":                            "Pausen",
+		"%s, %.1f a day":                    "%s, %.1f pro Tag",
+		"pomodoros":                         "Pomodoros",
+		"%d today, %.1f a day":              "%d heute, %.1f pro Tag",
+		"█ an hour or a pomodoro, over %d days": "█ eine Stunde oder ein Pomodoro, über %d Tage",
+		"OVERTIME BY WEEK":                      "ÜBERSTUNDEN PRO WOCHE",
+	},
+}
+
+// dayNames and monthNames are the abbreviated names of the days of the week, from
+// Sunday, and of the months in each language but English.
+var (
+	dayNames = map[string][7]string{
+		"fr": {"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		"de": {"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	}
+	monthNames = map[string][12]string{
+		"fr": {"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		"de": {"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sep.", "Okt.", "Nov.", "Dez."},
+	}
+)
+
+// formatDate formats t as time.Format does, the names of the day and of the month
+// ("Mon" and "Jan" in layout) in the language of the user interface.
+func formatDate(t time.Time, layout string) string {
+	s := t.Format(layout)
+	if names, ok := dayNames[language]; ok && strings.Contains(layout, "Mon") {
+		s = strings.Replace(s, t.Format("Mon"), names[t.Weekday()], 1)
+	}
+	if names, ok := monthNames[language]; ok && strings.Contains(layout, "Jan") {
+		s = strings.Replace(s, t.Format("Jan"), names[t.Month()-1], 1)
+	}
+	return s
+}
+
+// tr translates s into the language of the user interface.
+func tr(s string) string {
+	if translated, ok := catalogs[language][s]; ok {
+		return translated
+	}
+	return s
+}
+
+// trf translates format and formats it with args, see fmt.Sprintf.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// selectLanguage returns the language named name, or the one of the locale (LC_ALL,
+// LC_MESSAGES or LANG) when name is empty. Unknown locales fall back to English.
+func selectLanguage(name string) (string, error) {
+	if name != "" {
+		if !slices.Contains(languages, name) {
+			return "", fmt.Errorf("unknown language %q, expected one of %s", name, strings.Join(languages, ", "))
+		}
+		return name, nil
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(env)
+		if locale == "" {
+			continue
+		}
+		// e.g. fr_CH.UTF-8
+		if lang := strings.ToLower(locale[:min(2, len(locale))]); slices.Contains(languages, lang) {
+			return lang, nil
+		}
+		return "en", nil
+	}
+	return "en", nil
+}
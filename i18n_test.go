@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	day := time.Date(2025, time.March, 4, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		language string
+		layout   string
+		want     string
+	}{
+		{"en", "Mon 2 Jan", "Tue 4 Mar"},
+		{"fr", "Mon 2 Jan", "mar. 4 mars"},
+		{"de", "Mon 2 Jan 2006", "Di 4 März 2025"},
+		{"fr", "Mon 15:04", "mar. 09:30"},
+		{"de", "2006-01-02", "2025-03-04"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+" "+tt.layout, func(t *testing.T) {
+			defer func(previous string) { language = previous }(language)
+			language = tt.language
+			if got := formatDate(day, tt.layout); got != tt.want {
+				t.Errorf("formatDate(%q) = %q, want %q", tt.layout, got, tt.want)
+			}
+		})
+	}
+}
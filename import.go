@@ -0,0 +1,481 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// importFormats lists the formats of the import command.
+var importFormats = []string{"csv", "json", "toggl", "timeclock"}
+
+// importColumns lists the fields read from CSV files, see --columns.
+var importColumns = []string{"date", "start", "end", "project", "billable", "note"}
+
+// defaultColumns maps the fields to the columns of the CSV formats: the one of
+// timely export and the detailed export of Toggl Track.
+var defaultColumns = map[string]map[string]string{
+	"csv": {"date": "date", "start": "start", "end": "end", "project": "project", "billable": "billable", "note": "notes"},
+	"toggl": {"date": "Start date", "start": "Start time", "end": "End time", "project": "Project", "billable": "Billable",
+		"note": "Description"},
+}
+
+// importedSpan is a span read from a file, End is zero when it is open.
+type importedSpan struct {
+	Line       int
+	Start, End time.Time
+	Project    string
+	Billable   bool
+	Note       string
+	// The end of the span has a note, a project and a billable flag of its own in
+	// timeclock files
+	EndNote     string
+	EndProject  string
+	EndBillable bool
+	// Target is the target of the day of the span, zero when unknown
+	Target time.Duration
+	// Metadata is the one of the day of the span
+	Metadata map[string]string
+}
+
+// runImport implements the import command and returns the process exit code.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "csv", "input format: "+strings.Join(importFormats, ", "))
+	columns := fs.String("columns", "", "columns of the CSV fields, e.g. \"date=Day,start=From,end=To\", among "+strings.Join(importColumns, ", "))
+	dryRun := fs.Bool("dry-run", false, "print the days as they would be stored, without storing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely import [flags] [file]")
+		fmt.Fprintln(fs.Output(), "Adds the spans read from the file, or from stdin, to the stored entries of their day.")
+		fmt.Fprintln(fs.Output(), "Spans already stored are skipped, so that a file imported twice is recorded once.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	in := io.Reader(os.Stdin)
+	if name := fs.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var spans []importedSpan
+	var err error
+	switch *format {
+	case "csv", "toggl":
+		mapping, merr := columnMapping(defaultColumns[*format], *columns)
+		if merr != nil {
+			fmt.Fprintln(os.Stderr, merr)
+			return 2
+		}
+		spans, err = readCSVSpans(in, mapping, *columns != "")
+	case "json":
+		spans, err = readJSONSpans(in)
+	case "timeclock":
+		spans, err = readTimeclock(in)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q, expected one of %s\n", *format, strings.Join(importFormats, ", "))
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot import:", err)
+		return 1
+	}
+	if err := importSpans(spans, *dryRun); err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot import:", err)
+		return 1
+	}
+	return 0
+}
+
+// columnMapping returns the default columns of the fields, changed by the
+// assignments of columns: "date=Day,start=From".
+func columnMapping(defaults map[string]string, columns string) (map[string]string, error) {
+	mapping := maps.Clone(defaults)
+	for _, assignment := range strings.Split(columns, ",") {
+		if strings.TrimSpace(assignment) == "" {
+			continue
+		}
+		field, column, ok := strings.Cut(assignment, "=")
+		field = strings.TrimSpace(field)
+		if !ok || !slices.Contains(importColumns, field) {
+			return nil, fmt.Errorf("expected field=column with fields among %s, got %q", strings.Join(importColumns, ", "), assignment)
+		}
+		mapping[field] = strings.TrimSpace(column)
+	}
+	return mapping, nil
+}
+
+// readCSVSpans reads a span per line of a CSV file with a header, its columns named
+// by mapping. The date and start columns are required, the others are read when
+// present, or required as well when strict.
+func readCSVSpans(in io.Reader, mapping map[string]string, strict bool) ([]importedSpan, error) {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %w", err)
+	}
+	index := make(map[string]int)
+	for _, field := range importColumns {
+		column, ok := mapping[field]
+		if !ok {
+			continue
+		}
+		i := slices.IndexFunc(header, func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), column) })
+		if i < 0 && (strict || field == "date" || field == "start") {
+			return nil, fmt.Errorf("no column %q for the %s", column, field)
+		}
+		if i >= 0 {
+			index[field] = i
+		}
+	}
+
+	var spans []importedSpan
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return spans, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		value := func(field string) string {
+			if i, ok := index[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		span, err := newImportedSpan(line, value("date"), value("start"), value("end"))
+		if err != nil {
+			return nil, err
+		}
+		span.Project, span.Note = value("project"), value("note")
+		switch strings.ToLower(value("billable")) {
+		case "true", "yes", "1":
+			span.Billable = true
+		}
+		spans = append(spans, span)
+	}
+}
+
+// readJSONSpans reads the days written by timely export --format json, their target
+// and metadata going along with each of their spans. The notes of a span are the ones
+// of its start and its end, a single note being the one of its start. Spans are
+// numbered from 1 in the file, in place of lines.
+func readJSONSpans(in io.Reader) ([]importedSpan, error) {
+	var days []exportedDay
+	if err := json.NewDecoder(in).Decode(&days); err != nil {
+		return nil, err
+	}
+	var spans []importedSpan
+	for _, day := range days {
+		date, err := parseImportDate(day.Date)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range day.Spans {
+			span := importedSpan{Line: len(spans) + 1, Start: s.Start.In(date.Location()), Project: s.Project, Billable: s.Billable,
+				EndProject: s.Project, EndBillable: s.Billable, Target: time.Duration(day.TargetSeconds) * time.Second, Metadata: day.Metadata}
+			if !timeutils.SameDay(span.Start, date) {
+				return nil, fmt.Errorf("span %d: starts at %s, not on %s", span.Line, span.Start.Format(time.RFC3339), day.Date)
+			}
+			if s.End != nil {
+				if span.End = s.End.In(date.Location()); !span.End.After(span.Start) || !timeutils.SameDay(span.End, date) {
+					return nil, fmt.Errorf("span %d: the span must end after it starts, on the same day", span.Line)
+				}
+			}
+			if len(s.Notes) > 0 {
+				span.Note = s.Notes[0]
+			}
+			if len(s.Notes) > 1 {
+				span.EndNote = s.Notes[1]
+			}
+			spans = append(spans, span)
+		}
+	}
+	return spans, nil
+}
+
+// readTimeclock reads the clock-ins and clock-outs of a timeclock file, as written by
+// hledger, ledger and timely export: "i 2025-03-14 08:00:00 acme  standup" and
+// "o 2025-03-14 12:00:00". The account clocked in is the project of the span, see
+// timeclockProject, and the tags of the comments are read as timely export writes them.
+func readTimeclock(in io.Reader) ([]importedSpan, error) {
+	var spans []importedSpan
+	var open *importedSpan
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !slices.Contains([]string{"i", "I", "o", "O"}, fields[0]) {
+			// Comments, directives and the other entries of the journal
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("line %d: expected a date and a time", line)
+		}
+		span, err := newImportedSpan(line, fields[1], fields[2], "")
+		if err != nil {
+			return nil, err
+		}
+		account, description, tags := timeclockFields(scanner.Text(), fields[2])
+		switch fields[0] {
+		case "i", "I":
+			if open != nil {
+				return nil, fmt.Errorf("line %d: clock in while clocked in since line %d", line, open.Line)
+			}
+			span.Project, span.Note = timeclockProject(account), description
+			if span.Billable, err = timeclockFlag(tags, "billable", false); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if target, ok := tags["target"]; ok {
+				if span.Target, err = time.ParseDuration(target); err != nil {
+					return nil, fmt.Errorf("line %d: target: %w", line, err)
+				}
+			}
+			open = &span
+		default:
+			if open == nil {
+				return nil, fmt.Errorf("line %d: clock out without clock in", line)
+			}
+			if !timeutils.SameDay(span.Start, open.Start) || !span.Start.After(open.Start) {
+				return nil, fmt.Errorf("line %d: the clock out must follow the clock in of line %d on the same day", line, open.Line)
+			}
+			open.End, open.EndNote = span.Start, description
+			// The account names the session closed, the project may differ
+			open.EndProject = open.Project
+			if project, ok := tags["project"]; ok {
+				open.EndProject = project
+			}
+			if open.EndBillable, err = timeclockFlag(tags, "billable", open.Billable); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			spans = append(spans, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		spans = append(spans, *open)
+	}
+	return spans, scanner.Err()
+}
+
+// timeclockFields returns the account, the description and the tags of the timeclock
+// entry line whose time is at. Tags are the name:value pairs of the comment, which
+// follows a ; and separates them with commas.
+func timeclockFields(line, at string) (account, description string, tags map[string]string) {
+	_, rest, _ := strings.Cut(line, at)
+	rest, comment, _ := strings.Cut(rest, ";")
+	// The account may hold spaces, two spaces separate it from the description
+	account, description, _ = strings.Cut(strings.TrimSpace(rest), "  ")
+	tags = make(map[string]string)
+	for _, tag := range strings.Split(comment, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(tag), ":")
+		if ok && name != "" && !strings.ContainsAny(name, " \t") {
+			tags[name] = strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(account), strings.TrimSpace(description), tags
+}
+
+// timeclockProject returns the project of a timeclock account: none for the account
+// of timely export, and the subaccount for its subaccounts. Other accounts are
+// projects as they are.
+func timeclockProject(account string) string {
+	if account == timeclockAccount {
+		return ""
+	}
+	if project, ok := strings.CutPrefix(account, timeclockAccount+":"); ok {
+		return project
+	}
+	return account
+}
+
+// timeclockFlag returns the flag set by the tag name, def when there is none. A tag
+// without value sets the flag.
+func timeclockFlag(tags map[string]string, name string, def bool) (bool, error) {
+	value, ok := tags[name]
+	switch {
+	case !ok:
+		return def, nil
+	case value == "":
+		return true, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: expected true or false, got %q", name, value)
+	}
+	return flag, nil
+}
+
+// newImportedSpan parses the date, the start and the end of a span, the end may be
+// empty for an open span.
+func newImportedSpan(line int, date, start, end string) (importedSpan, error) {
+	day, err := parseImportDate(date)
+	if err != nil {
+		return importedSpan{}, fmt.Errorf("line %d: %w", line, err)
+	}
+	span := importedSpan{Line: line}
+	if span.Start, err = parseImportTime(start, day); err != nil {
+		return importedSpan{}, fmt.Errorf("line %d: %w", line, err)
+	}
+	if end == "" {
+		return span, nil
+	}
+	if span.End, err = parseImportTime(end, day); err != nil {
+		return importedSpan{}, fmt.Errorf("line %d: %w", line, err)
+	}
+	if !span.End.After(span.Start) {
+		return importedSpan{}, fmt.Errorf("line %d: the span ends at %s, not after it starts, spans over midnight are not supported",
+			line, timeutils.FormatTime(span.End))
+	}
+	return span, nil
+}
+
+// parseImportDate parses the dates of other tools: 2025-03-14 or 2025/03/14.
+func parseImportDate(s string) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", strings.ReplaceAll(s, "/", "-"), homeLocation())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q: expected a date as YYYY-MM-DD", s)
+	}
+	return day, nil
+}
+
+// parseImportTime parses a time of day, with or without seconds which are dropped,
+// on day.
+func parseImportTime(s string, day time.Time) (time.Time, error) {
+	if t, err := time.Parse("15:04:05", s); err == nil {
+		return timeutils.OnDay(t, day).Truncate(time.Minute), nil
+	}
+	return timeutils.ParseTimeOn(s, day)
+}
+
+// importSpans adds the spans to the stored entries of their day and prints the days
+// changed. Spans already stored are skipped. With dryRun, nothing is stored.
+func importSpans(spans []importedSpan, dryRun bool) error {
+	store, err := dayStore()
+	if err != nil {
+		return err
+	}
+	days := make(map[string]tracking.Day)
+	var order []time.Time
+	added := make(map[string]int)
+	changed := make(map[string]bool)
+	for _, span := range spans {
+		key := span.Start.Format("2006-01-02")
+		day, ok := days[key]
+		if !ok {
+			if day, err = store.Load(span.Start); err != nil {
+				return err
+			}
+			order = append(order, timeutils.StartOfDay(span.Start))
+		}
+		before := day
+		day, isNew, err := mergeSpan(day, span)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			added[key]++
+		}
+		if isNew || day.Target != before.Target || !maps.Equal(day.Metadata, before.Metadata) {
+			changed[key] = true
+		}
+		days[key] = day
+	}
+
+	slices.SortFunc(order, func(a, b time.Time) int { return a.Compare(b) })
+	for _, t := range order {
+		key := t.Format("2006-01-02")
+		if !changed[key] {
+			continue
+		}
+		if !dryRun {
+			if err := store.Save(t, days[key]); err != nil {
+				return err
+			}
+		}
+		spans := "spans"
+		if added[key] == 1 {
+			spans = "span"
+		}
+		fmt.Printf("%s %s (%d %s added)\n", key, daySummary(days[key].Entries), added[key], spans)
+	}
+	if len(changed) == 0 {
+		fmt.Println("Nothing to import, the spans are already stored")
+	} else if dryRun {
+		fmt.Println("Dry run, nothing was stored")
+	}
+	return nil
+}
+
+// mergeSpan returns day with span added, and whether the span was not already there.
+// The target of the span becomes the one of the day when it has none, and its metadata
+// fills in the keys the day lacks.
+func mergeSpan(day tracking.Day, span importedSpan) (tracking.Day, bool, error) {
+	entries, isNew, err := addSpan(day.Entries, span)
+	if err != nil {
+		return tracking.Day{}, false, err
+	}
+	if isNew {
+		day.Entries = entries
+	}
+	if day.Target == 0 {
+		day.Target = span.Target
+	}
+	missing := make(map[string]string)
+	for key, value := range span.Metadata {
+		if _, ok := day.Metadata[key]; !ok {
+			missing[key] = value
+		}
+	}
+	if len(missing) > 0 {
+		day.Metadata = withMetadata(day.Metadata, missing)
+	}
+	return day, isNew, nil
+}
+
+// addSpan returns entries with span added, and whether it was not already there. A
+// span overlapping the ones of entries is an error.
+func addSpan(entries tracking.Entries, span importedSpan) (tracking.Entries, bool, error) {
+	spans := pairs(entries)
+	for _, p := range spans {
+		var end time.Time
+		if len(p) > 1 {
+			end = p[1].Time
+		}
+		if p[0].Time.Equal(span.Start) && end.Equal(span.End) {
+			return entries, false, nil
+		}
+		// Zero ends are open spans, which run forever
+		if (end.IsZero() || span.Start.Before(end)) && (span.End.IsZero() || p[0].Time.Before(span.End)) {
+			return nil, false, fmt.Errorf("line %d: the span overlaps %s, already stored", span.Line, spanText(p))
+		}
+	}
+
+	added := tracking.Entries{{Time: span.Start, Note: span.Note, Project: span.Project, Billable: span.Billable}}
+	if !span.End.IsZero() {
+		added = append(added, tracking.Entry{Time: span.End, Note: span.EndNote, Project: span.EndProject, Billable: span.EndBillable})
+	}
+	// Spans are kept whole, a span starting when another ends must follow it
+	spans = append(spans, added)
+	slices.SortStableFunc(spans, func(a, b tracking.Entries) int { return a[0].Time.Compare(b[0].Time) })
+	return slices.Concat(spans...), true, nil
+}
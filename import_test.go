@@ -0,0 +1,184 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// importAt returns the time hour:min on 14 March 2025, the day of the import tests.
+func importAt(hour, min int) time.Time {
+	return time.Date(2025, time.March, 14, hour, min, 0, 0, time.Local)
+}
+
+func TestReadCSVSpans(t *testing.T) {
+	toggl, err := columnMapping(defaultColumns["toggl"], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		mapping map[string]string
+		strict  bool
+		input   string
+		want    []importedSpan
+		wantErr string
+	}{
+		{"timely export", defaultColumns["csv"], false,
+			"date,start,end,duration,project,billable,notes\n" +
+				"2025-03-14,08:00,12:00,04:00,acme,true,standup\n" +
+				"2025-03-14,13:00,,,,false,\n",
+			[]importedSpan{
+				{Line: 2, Start: importAt(8, 0), End: importAt(12, 0), Project: "acme", Billable: true, Note: "standup"},
+				{Line: 3, Start: importAt(13, 0)},
+			}, ""},
+		{"toggl with seconds and slashes", toggl, true,
+			"Description,Project,Billable,Start date,Start time,End date,End time\n" +
+				"call,web,Yes,2025/03/14,08:00:59,2025/03/14,09:30:00\n",
+			[]importedSpan{{Line: 2, Start: importAt(8, 0), End: importAt(9, 30), Project: "web", Billable: true, Note: "call"}}, ""},
+		{"header in another case with optional columns missing", defaultColumns["csv"], false,
+			" Date ,START\n2025-03-14,8:00\n",
+			[]importedSpan{{Line: 2, Start: importAt(8, 0)}}, ""},
+		{"missing start column", defaultColumns["csv"], false, "date,end\n2025-03-14,12:00\n", nil, `no column "start"`},
+		{"missing optional column when strict", toggl, true, "Start date,Start time\n2025-03-14,08:00\n", nil, `no column "End time"`},
+		{"span over midnight", defaultColumns["csv"], false, "date,start,end\n2025-03-14,22:00,01:00\n", nil,
+			"line 2: the span ends at 01:00"},
+		{"invalid date", defaultColumns["csv"], false, "date,start\n14.03.2025,08:00\n", nil, "line 2:"},
+		{"empty file", defaultColumns["csv"], false, "", nil, "missing header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readCSVSpans(strings.NewReader(tt.input), tt.mapping, tt.strict)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readCSVSpans() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readCSVSpans() error = %v", err)
+			}
+			assertSpans(t, got, tt.want)
+		})
+	}
+}
+
+func TestReadTimeclock(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []importedSpan
+		wantErr string
+	}{
+		{"hledger", "; worked\n" +
+			"i 2025/03/14 08:00:00 client:acme  standup\n" +
+			"o 2025/03/14 12:00:00\n\n" +
+			"2025/03/14 other transaction\n" +
+			"i 2025/03/14 13:00:00 internal\n" +
+			"o 2025/03/14 17:30:00\n",
+			[]importedSpan{
+				{Line: 2, Start: importAt(8, 0), End: importAt(12, 0), Project: "client:acme", Note: "standup", EndProject: "client:acme"},
+				{Line: 6, Start: importAt(13, 0), End: importAt(17, 30), Project: "internal", EndProject: "internal"},
+			}, ""},
+		{"timely export", "i 2025/03/14 08:00:00 timely:acme  call  ; target:8h0m0s, billable:\n" +
+			"o 2025/03/14 09:00:00 timely:acme  done  ; project:web, billable:false\n" +
+			"i 2025/03/14 09:00:00 timely\n",
+			[]importedSpan{
+				{Line: 1, Start: importAt(8, 0), End: importAt(9, 0), Project: "acme", Billable: true, Note: "call",
+					EndNote: "done", EndProject: "web", Target: 8 * time.Hour},
+				{Line: 3, Start: importAt(9, 0)},
+			}, ""},
+		{"uppercase codes", "I 2025-03-14 08:00:00\nO 2025-03-14 09:00:00\n",
+			[]importedSpan{{Line: 1, Start: importAt(8, 0), End: importAt(9, 0)}}, ""},
+		{"clock in while clocked in", "i 2025/03/14 08:00:00\ni 2025/03/14 09:00:00\n", nil,
+			"line 2: clock in while clocked in since line 1"},
+		{"clock out without clock in", "o 2025/03/14 09:00:00\n", nil, "line 1: clock out without clock in"},
+		{"clock out on another day", "i 2025/03/14 22:00:00\no 2025/03/15 01:00:00\n", nil, "line 2: the clock out must follow"},
+		{"clock out before the clock in", "i 2025/03/14 09:00:00\no 2025/03/14 08:00:00\n", nil, "line 2: the clock out must follow"},
+		{"missing time", "i 2025/03/14\n", nil, "line 1: expected a date and a time"},
+		{"invalid target", "i 2025/03/14 08:00:00 timely  ; target:8 hours\n", nil, "line 1: target:"},
+		{"invalid billable flag", "i 2025/03/14 08:00:00 timely  ; billable:maybe\n", nil, "line 1: billable: expected true or false"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readTimeclock(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readTimeclock() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readTimeclock() error = %v", err)
+			}
+			assertSpans(t, got, tt.want)
+		})
+	}
+}
+
+func TestAddSpan(t *testing.T) {
+	stored := tracking.Entries{{Time: importAt(8, 0)}, {Time: importAt(12, 0)}, {Time: importAt(13, 0)}, {Time: importAt(15, 0)}}
+	tests := []struct {
+		name    string
+		entries tracking.Entries
+		span    importedSpan
+		want    tracking.Entries
+		wantNew bool
+		wantErr string
+	}{
+		{"into an empty day", nil, importedSpan{Start: importAt(8, 0), End: importAt(9, 0), Note: "call", EndProject: "acme"},
+			tracking.Entries{{Time: importAt(8, 0), Note: "call"}, {Time: importAt(9, 0), Project: "acme"}}, true, ""},
+		{"already stored", stored, importedSpan{Start: importAt(13, 0), End: importAt(15, 0)}, stored, false, ""},
+		{"in a break", stored, importedSpan{Start: importAt(12, 15), End: importAt(12, 45)},
+			tracking.Entries{{Time: importAt(8, 0)}, {Time: importAt(12, 0)}, {Time: importAt(12, 15)}, {Time: importAt(12, 45)},
+				{Time: importAt(13, 0)}, {Time: importAt(15, 0)}}, true, ""},
+		// The span starting when another ends follows it, rather than closing it
+		{"touching spans", stored, importedSpan{Start: importAt(12, 0), End: importAt(13, 0), Project: "acme"},
+			tracking.Entries{{Time: importAt(8, 0)}, {Time: importAt(12, 0)}, {Time: importAt(12, 0), Project: "acme"}, {Time: importAt(13, 0)},
+				{Time: importAt(13, 0)}, {Time: importAt(15, 0)}}, true, ""},
+		{"open span after the others", stored, importedSpan{Start: importAt(16, 0)},
+			append(stored, tracking.Entry{Time: importAt(16, 0)}), true, ""},
+		{"overlap", stored, importedSpan{Line: 7, Start: importAt(11, 0), End: importAt(12, 30)}, nil, false,
+			"line 7: the span overlaps 08:00-12:00"},
+		{"open span overlapping", stored, importedSpan{Line: 3, Start: importAt(14, 0)}, nil, false, "line 3: the span overlaps 13:00-15:00"},
+		{"after a stored open span", tracking.Entries{{Time: importAt(8, 0)}}, importedSpan{Line: 2, Start: importAt(9, 0), End: importAt(10, 0)},
+			nil, false, "line 2: the span overlaps 08:00-…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isNew, err := addSpan(tt.entries, tt.span)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("addSpan() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("addSpan() error = %v", err)
+			}
+			if !got.Equal(tt.want) || isNew != tt.wantNew {
+				t.Errorf("addSpan() = %v, %v, want %v, %v", got, isNew, tt.want, tt.wantNew)
+			}
+		})
+	}
+}
+
+// assertSpans fails the test when the spans read differ from the ones expected.
+func assertSpans(t *testing.T, got, want []importedSpan) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("read %d spans %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Line != w.Line || !g.Start.Equal(w.Start) || !g.End.Equal(w.End) || g.Project != w.Project || g.Billable != w.Billable ||
+			g.Note != w.Note || g.EndNote != w.EndNote || g.EndProject != w.EndProject || g.EndBillable != w.EndBillable || g.Target != w.Target {
+			t.Errorf("span %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
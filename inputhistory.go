@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fredjeck/timely/pkg/platform"
+)
+
+const (
+	// inputHistoryFile is the name of the file, within the data directory, keeping the inputs entered.
+	inputHistoryFile = "history"
+	// inputHistorySize is the number of inputs remembered.
+	inputHistorySize = 50
+)
+
+// loadInputHistory returns the inputs entered during previous launches, the most recent first.
+func loadInputHistory() []string {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return nil
+	}
+	b, err := os.ReadFile(filepath.Join(dir, inputHistoryFile))
+	if err != nil {
+		return nil
+	}
+	var inputs []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			inputs = append(inputs, line)
+		}
+	}
+	return inputs
+}
+
+// saveInputHistory keeps the inputs for the next launches, this is best effort.
+func saveInputHistory(inputs []string) {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, inputHistoryFile), []byte(strings.Join(inputs, "\n")+"\n"), 0o600)
+}
+
+// SetInputHistory makes inputs, the most recent first, the suggestions of the input.
+func (m model) SetInputHistory(inputs []string) model {
+	m.inputs = inputs
+	m.recall = 0
+	m.textInput.SetSuggestions(inputs)
+	return m
+}
+
+// Remember records value as the most recent input, it is suggested while typing
+// from then on.
+func (m model) Remember(value string) model {
+	value = strings.TrimSpace(value)
+	inputs := slices.DeleteFunc(slices.Clone(m.inputs), func(s string) bool { return s == value })
+	inputs = append([]string{value}, inputs...)
+	if len(inputs) > inputHistorySize {
+		inputs = inputs[:inputHistorySize]
+	}
+	saveInputHistory(inputs)
+	return m.SetInputHistory(inputs)
+}
+
+// recalling reports whether up and down go through the previous inputs: the input
+// has the focus and is empty, or holds the one recalled last. Otherwise they go
+// through the inputs starting with what was typed, or move the selection.
+func (m model) recalling() bool {
+	if !m.typing {
+		return false
+	}
+	value := m.textInput.Value()
+	return value == "" || m.recall > 0 && m.recall <= len(m.inputs) && value == m.inputs[m.recall-1]
+}
+
+// Recall fills the input with the previous input step inputs older than the one
+// recalled last, from an empty input. Going past the most recent one empties it.
+func (m model) Recall(step int) model {
+	if m.textInput.Value() == "" {
+		m.recall = 0
+	}
+	m.recall = max(min(m.recall+step, len(m.inputs)), 0)
+	m.recalled = false
+	if m.recall == 0 {
+		m.textInput.Reset()
+		return m
+	}
+	m.textInput.SetValue(m.inputs[m.recall-1])
+	return m
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_Recall(t *testing.T) {
+	m := initialModel(8*time.Hour, 0, 0, nil).SetInputHistory([]string{"1245 lunch", "8:00", "1300"})
+	press := func(m model, k tea.KeyType) model {
+		updated, _ := m.Update(tea.KeyMsg{Type: k})
+		return updated.(model)
+	}
+
+	m = typeKeys(m, "i")
+	tests := []struct {
+		key  tea.KeyType
+		want string
+	}{
+		{tea.KeyUp, "1245 lunch"},
+		{tea.KeyUp, "8:00"},
+		{tea.KeyUp, "1300"},
+		{tea.KeyUp, "1300"},
+		{tea.KeyDown, "8:00"},
+		{tea.KeyDown, "1245 lunch"},
+		{tea.KeyDown, ""},
+		{tea.KeyDown, ""},
+		{tea.KeyUp, "1245 lunch"},
+	}
+	for i, tt := range tests {
+		if m = press(m, tt.key); m.textInput.Value() != tt.want {
+			t.Fatalf("key %d (%s) recalls %q, want %q", i, tt.key, m.textInput.Value(), tt.want)
+		}
+	}
+
+	// Once changed, up and down go through the inputs starting with the text
+	m = press(m, tea.KeyBackspace)
+	m = press(m, tea.KeyBackspace)
+	if m = press(m, tea.KeyUp); m.textInput.Value() != "1245 lun" || m.recall != 1 {
+		t.Errorf("up after a change gives %q, want the text kept", m.textInput.Value())
+	}
+
+	// Without the focus, up moves the selection
+	m = press(m, tea.KeyEsc)
+	if m = press(m, tea.KeyUp); m.textInput.Value() != "" {
+		t.Errorf("up without the focus recalls %q", m.textInput.Value())
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap lists the key bindings of the tracker, it implements help.KeyMap.
+type keyMap struct {
+	Add       key.Binding
+	Type      key.Binding
+	Now       key.Binding
+	Edit      key.Binding
+	Note      key.Binding
+	Cancel    key.Binding
+	Delete    key.Binding
+	Undo      key.Binding
+	Redo      key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	First     key.Binding
+	Last      key.Binding
+	PrevDay   key.Binding
+	NextDay   key.Binding
+	Copy      key.Binding
+	CopyLines key.Binding
+	Pomodoro  key.Binding
+	Compact   key.Binding
+	Lunch     key.Binding
+	Project   key.Binding
+	Billable  key.Binding
+	Target    key.Binding
+	Countdown key.Binding
+	Freeze    key.Binding
+	Docs      key.Binding
+	View      key.Binding
+	Help      key.Binding
+	Quit      key.Binding
+	ForceQuit key.Binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Add: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", tr("add typed time")),
+		),
+		Type: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", tr("type")),
+		),
+		Now: key.NewBinding(
+			key.WithKeys("n", " "),
+			key.WithHelp("n/space", tr("clock now")),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", tr("edit")),
+		),
+		Note: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", tr("annotate")),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", tr("cancel/back")),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", tr("delete")),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", tr("undo")),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", tr("redo")),
+		),
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", tr("move up")),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", tr("move down")),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left"),
+			key.WithHelp("←", tr("previous day in the calendar")),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right"),
+			key.WithHelp("→", tr("next day in the calendar")),
+		),
+		First: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("home/g", tr("first")),
+		),
+		Last: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("end/G", tr("last")),
+		),
+		PrevDay: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", tr("previous day")),
+		),
+		NextDay: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", tr("next day")),
+		),
+		Copy: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", tr("copy summary")),
+		),
+		CopyLines: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", tr("copy detailed summary")),
+		),
+		Pomodoro: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", tr("pomodoro")),
+		),
+		Compact: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", tr("compact mode")),
+		),
+		Lunch: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", tr("lunch break")),
+		),
+		Project: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", tr("switch project")),
+		),
+		Billable: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", tr("toggle billable")),
+		),
+		Target: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", tr("change target")),
+		),
+		Countdown: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", tr("countdown")),
+		),
+		Freeze: key.NewBinding(
+			key.WithKeys("z", "ctrl+z"),
+			key.WithHelp("z", tr("freeze screen")),
+		),
+		Docs: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", tr("docs")),
+		),
+		View: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", tr("next view")),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", tr("help")),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q"),
+			key.WithHelp("q", tr("quit")),
+		),
+		ForceQuit: key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", tr("force quit")),
+		),
+	}
+}
+
+// ShortHelp returns the bindings displayed in the footer.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Now, k.Edit, k.Delete, k.Undo, k.Help, k.Quit}
+}
+
+// FullHelp returns the bindings displayed in the help overlay, by column.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Add, k.Type, k.Now, k.Edit, k.Note, k.Cancel, k.Delete},
+		{k.Undo, k.Redo, k.Up, k.Down, k.Left, k.Right, k.First, k.Last, k.PrevDay, k.NextDay, k.Lunch, k.Copy, k.CopyLines},
+		{k.Project, k.Billable, k.Target, k.Pomodoro, k.Compact, k.Countdown, k.Freeze, k.Docs, k.View, k.Help, k.Quit, k.ForceQuit},
+	}
+}
+
+// bindings maps the action names used to configure the keys to the bindings of the key map.
+func (k *keyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"add":        &k.Add,
+		"type":       &k.Type,
+		"now":        &k.Now,
+		"edit":       &k.Edit,
+		"note":       &k.Note,
+		"cancel":     &k.Cancel,
+		"delete":     &k.Delete,
+		"undo":       &k.Undo,
+		"redo":       &k.Redo,
+		"up":         &k.Up,
+		"down":       &k.Down,
+		"left":       &k.Left,
+		"right":      &k.Right,
+		"first":      &k.First,
+		"last":       &k.Last,
+		"prev-day":   &k.PrevDay,
+		"next-day":   &k.NextDay,
+		"copy":       &k.Copy,
+		"copy-lines": &k.CopyLines,
+		"pomodoro":   &k.Pomodoro,
+		"compact":    &k.Compact,
+		"lunch":      &k.Lunch,
+		"project":    &k.Project,
+		"billable":   &k.Billable,
+		"target":     &k.Target,
+		"countdown":  &k.Countdown,
+		"freeze":     &k.Freeze,
+		"docs":       &k.Docs,
+		"view":       &k.View,
+		"help":       &k.Help,
+		"quit":       &k.Quit,
+		"force-quit": &k.ForceQuit,
+	}
+}
+
+// keyName returns how a key is displayed in the help.
+func keyName(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// Rebind returns a copy of the key map in which the actions of overrides are bound
+// to the given keys instead of the default ones. Each key can only be bound to one action.
+func (k keyMap) Rebind(overrides map[string][]string) (keyMap, error) {
+	original := k
+	bindings := k.bindings()
+	for action, keys := range overrides {
+		b, ok := bindings[action]
+		if !ok {
+			return original, fmt.Errorf("unknown action %q", action)
+		}
+		if len(keys) == 0 {
+			return original, fmt.Errorf("no key given for %q", action)
+		}
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = keyName(key)
+		}
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(names, "/"), b.Help().Desc)
+	}
+
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	used := map[string]string{}
+	for _, action := range actions {
+		for _, key := range bindings[action].Keys() {
+			if other, ok := used[key]; ok {
+				return original, fmt.Errorf("%q is bound to both %q and %q", keyName(key), other, action)
+			}
+			used[key] = action
+		}
+	}
+	return k, nil
+}
+
+// parseKeyOverrides parses space separated assignments of keys to actions, several
+// keys being separated by commas: "quit=ctrl+q now=n,space". The space bar is named "space".
+func parseKeyOverrides(s string) (map[string][]string, error) {
+	overrides := map[string][]string{}
+	for _, assignment := range strings.Fields(s) {
+		action, keys, ok := strings.Cut(assignment, "=")
+		if !ok || keys == "" {
+			return nil, fmt.Errorf("expected action=keys, got %q", assignment)
+		}
+		for _, key := range strings.Split(keys, ",") {
+			if key == "space" {
+				key = " "
+			}
+			overrides[action] = append(overrides[action], key)
+		}
+	}
+	return overrides, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseKeyOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string][]string
+		wantErr string
+	}{
+		{"empty", "", map[string][]string{}, ""},
+		{"several actions", "quit=ctrl+q now=n,space", map[string][]string{"quit": {"ctrl+q"}, "now": {"n", " "}}, ""},
+		{"repeated action", "now=n now=N", map[string][]string{"now": {"n", "N"}}, ""},
+		{"unknown actions are left to Rebind", "fly=f", map[string][]string{"fly": {"f"}}, ""},
+		{"missing keys", "quit=", nil, `expected action=keys, got "quit="`},
+		{"missing equal sign", "quit", nil, `expected action=keys, got "quit"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKeyOverrides(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseKeyOverrides(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseKeyOverrides(%q) error = %v", tt.input, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseKeyOverrides(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			for action, keys := range tt.want {
+				if !slices.Equal(got[action], keys) {
+					t.Errorf("parseKeyOverrides(%q)[%s] = %q, want %q", tt.input, action, got[action], keys)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyMap_Rebind(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		action    string
+		wantKeys  []string
+		wantHelp  string
+		wantErr   string
+	}{
+		{"rebound", map[string][]string{"quit": {"ctrl+q"}}, "quit", []string{"ctrl+q"}, "ctrl+q", ""},
+		{"space in the help", map[string][]string{"now": {"N", " "}}, "now", []string{"N", " "}, "N/space", ""},
+		{"swapped keys", map[string][]string{"undo": {"r"}, "redo": {"u"}}, "redo", []string{"u"}, "u", ""},
+		{"unknown action", map[string][]string{"fly": {"f"}}, "", nil, "", `unknown action "fly"`},
+		{"no key", map[string][]string{"quit": {}}, "", nil, "", `no key given for "quit"`},
+		{"key of another action", map[string][]string{"quit": {"x"}}, "", nil, "", `"x" is bound to both "delete" and "quit"`},
+		{"key given twice", map[string][]string{"quit": {"Q"}, "help": {"Q"}}, "", nil, "", `"Q" is bound to both "help" and "quit"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := defaultKeyMap()
+			got, err := defaults.Rebind(tt.overrides)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Rebind() error = %v, want %q", err, tt.wantErr)
+				}
+				// The key map is left as it was
+				if !slices.Equal(got.Quit.Keys(), defaults.Quit.Keys()) {
+					t.Errorf("Rebind() failing binds quit to %q", got.Quit.Keys())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Rebind() error = %v", err)
+			}
+			b := got.bindings()[tt.action]
+			if !slices.Equal(b.Keys(), tt.wantKeys) || b.Help().Key != tt.wantHelp {
+				t.Errorf("%s is bound to %q, help %q, want %q, %q", tt.action, b.Keys(), b.Help().Key, tt.wantKeys, tt.wantHelp)
+			}
+			if len(defaults.bindings()[tt.action].Keys()) == 0 || slices.Equal(defaults.bindings()[tt.action].Keys(), tt.wantKeys) {
+				t.Errorf("Rebind() changed the default key map")
+			}
+		})
+	}
+}
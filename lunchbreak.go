@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// defaultLunchBreak is the length of the lunch break taken with l.
+const defaultLunchBreak = "45m"
+
+// lunchBreak is the standard lunch break taken with l: either a length or the
+// time at which work resumes.
+type lunchBreak struct {
+	length time.Duration
+	until  time.Time // only the hour and minute are used
+}
+
+// parseLunchBreak parses a lunch break written as a duration ("45m") or as the
+// time work resumes at ("13:00").
+func parseLunchBreak(s string) (lunchBreak, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return lunchBreak{}, fmt.Errorf("the lunch break must be positive, got %q", s)
+		}
+		return lunchBreak{length: d}, nil
+	}
+	t, err := timeutils.ParseTime(s)
+	if err != nil {
+		return lunchBreak{}, fmt.Errorf("expected a duration or a time, got %q", s)
+	}
+	return lunchBreak{until: t}, nil
+}
+
+// end returns when a lunch break starting at start ends.
+func (b lunchBreak) end(start time.Time) time.Time {
+	if b.length > 0 {
+		return start.Add(b.length)
+	}
+	return time.Date(start.Year(), start.Month(), start.Day(), b.until.Hour(), b.until.Minute(), 0, 0, start.Location())
+}
+
+// TakeLunch clocks out for the lunch break, clocking back in is scheduled at its
+// end. Taking it again while it is scheduled cancels the clock in.
+func (m model) TakeLunch(now time.Time) (model, error) {
+	if !m.lunchReturn.IsZero() {
+		m.lunchReturn = time.Time{}
+		return m, nil
+	}
+	// Already clocked out, the break started with the last entry
+	start := m.durations.Last()
+	if len(m.entries)%2 == 1 {
+		start = now
+	}
+	if start.IsZero() {
+		return m, errors.New("nothing recorded yet, no lunch break to take")
+	}
+	back := m.lunchBreak.end(start)
+	if !back.After(now) {
+		return m, fmt.Errorf("the lunch break would already be over at %s", timeutils.FormatTime(back))
+	}
+	if len(m.entries)%2 == 1 {
+		m = m.Append(now)
+	}
+	m.lunchReturn = back
+	return m, nil
+}
+
+// updateLunch clocks back in once the lunch break is over, unless it was done by hand.
+func (m model) updateLunch(now time.Time) model {
+	if m.lunchReturn.IsZero() || now.Before(m.lunchReturn) {
+		return m
+	}
+	back := m.lunchReturn
+	m.lunchReturn = time.Time{}
+	if len(m.entries)%2 == 1 || !back.After(m.durations.Last()) {
+		return m
+	}
+	return m.Append(back)
+}
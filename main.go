@@ -1,13 +1,20 @@
 package main
 
 import (
+	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"strings"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/progress"
@@ -16,56 +23,191 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/fredjeck/timely/pkg/platform"
 	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+	"github.com/fredjeck/timely/pkg/widget"
 )
 
-type systemStartupTime time.Time
+// systemStartup carries the detected startup time and the provider which detected it.
+type systemStartup struct {
+	time   time.Time
+	source string
+}
+
+// systemBootTime is the time at which the machine booted, derived from its uptime.
+type systemBootTime time.Time
+
+// systemResumed is sent when the system wakes up after having been suspended.
+type systemResumed platform.SuspendEvent
+
+// minuteTick refreshes the provisional figures, which grow with the open span.
+type minuteTick time.Time
+
+// flashTick drives the flashing of the progress bars once the target is reached.
+type flashTick struct{}
+
+// awaitInteraction is sent when the startup time cannot be detected and the
+// first user interaction should be used instead.
+type awaitInteraction struct{}
+
+// systemWakes lists the times at which the system resumed from sleep today.
+type systemWakes []time.Time
+
+// powerChanged is sent when the machine is plugged in or unplugged.
+type powerChanged platform.PowerChange
+
+// userIdle reports for how long the user has not touched the keyboard or mouse.
+type userIdle time.Duration
 
 const listHeight = 14
 const defaultWidth = 20
 const padding = 4
 const maxWidth = 80
+const suspendCheckInterval = 15 * time.Second
+const idleCheckInterval = 30 * time.Second
+const powerCheckInterval = 10 * time.Second
+
+// awayThreshold is the idle time from which the user is considered away from the machine.
+const awayThreshold = time.Minute
+const flashInterval = 500 * time.Millisecond
+const flashCount = 6
+const breakBarWidth = 20
+
+// What to do with an open span when quitting, see --on-quit.
+const (
+	quitAsk      = "ask"
+	quitClockOut = "clock-out"
+	quitAnyway   = "quit"
+)
 
+// Colors are set by the active theme, see theme.apply
 var (
 	titleStyle        = lipgloss.NewStyle().MarginLeft(2)
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
-	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("170"))
+	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2)
 	paginationStyle   = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
 	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 	quitTextStyle     = lipgloss.NewStyle().Margin(1, 0, 2, 4)
-	unreachedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000ff")).Bold(true)
-	reachedStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Bold(true)
-	helperStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+	unreachedStyle    = lipgloss.NewStyle().Bold(true)
+	warningStyle      = lipgloss.NewStyle().Bold(true)
+	errorStyle        = lipgloss.NewStyle()
+	bannerStyle       = lipgloss.NewStyle().Bold(true).Border(lipgloss.ThickBorder()).Padding(1, 3)
+	reachedStyle      = lipgloss.NewStyle().Bold(true)
+	helperStyle       = lipgloss.NewStyle()
+	openItemStyle     = itemStyle.Bold(true)
+	clockedInStyle    = lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	clockedOutStyle   = lipgloss.NewStyle().Bold(true).Padding(0, 1)
 )
 
-type item string
+// row is a line of the list: a span with its duration, or the break between two spans.
+type row struct {
+	first int              // index of the first entry of the span
+	span  tracking.Entries // the closing entry is missing while the span runs
+	pause time.Duration    // length of the break, on break rows
+}
+
+func (r row) FilterValue() string { return "" }
 
-func (i item) FilterValue() string { return "" }
+// rows lays the entries out as spans separated by breaks.
+func rows(entries tracking.Entries) []list.Item {
+	var items []list.Item
+	for i, span := range pairs(entries) {
+		if i > 0 {
+			items = append(items, row{pause: span[0].Time.Sub(entries[2*i-1].Time)})
+		}
+		items = append(items, row{first: 2 * i, span: span})
+	}
+	return items
+}
 
-type itemDelegate struct{}
+// rowOf returns the row of the list showing the entry at index i.
+func rowOf(i int) int {
+	// Each span but the first one is preceded by a break
+	return i / 2 * 2
+}
+
+// entryTime formats the time of an entry, along with its day when it is not today's.
+func entryTime(t time.Time) string {
+	if !timeutils.SameDay(t, time.Now().In(t.Location())) {
+		return formatDate(t, "Mon 15:04")
+	}
+	return timeutils.FormatTime(t)
+}
+
+// itemDelegate renders the rows, selected is the index of the selected entry
+// which is highlighted within its span. The open span of a past day is not running.
+type itemDelegate struct {
+	selected int
+	past     bool
+}
 
 func (d itemDelegate) Height() int                             { return 1 }
 func (d itemDelegate) Spacing() int                            { return 0 }
 func (d itemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	i, ok := listItem.(item)
+	r, ok := listItem.(row)
 	if !ok {
 		return
 	}
+	if r.span == nil {
+		if r.pause == 0 {
+			// Spans joined by a project switch
+			fmt.Fprint(w, itemStyle.Render(helperStyle.Render("  ↳ "+tr("project switch"))))
+			return
+		}
+		fmt.Fprint(w, itemStyle.Render(helperStyle.Render("  "+tr("break")+" "+timeutils.FormatDuration(r.pause))))
+		return
+	}
 
-	fn := itemStyle.Render
-	if index == m.Index() {
-		fn = func(s ...string) string {
-			return selectedItemStyle.Render("> " + strings.Join(s, " "))
+	// A span without closing entry is the one currently running
+	open := len(r.span) == 1
+	style := itemStyle.UnsetPaddingLeft()
+	if open {
+		style = openItemStyle.UnsetPaddingLeft()
+	}
+	times := make([]string, 2)
+	var notes []string
+	for j, e := range r.span {
+		times[j] = style.Render(entryTime(e.Time))
+		if r.first+j == d.selected {
+			times[j] = selectedItemStyle.UnsetPaddingLeft().Render(entryTime(e.Time))
+		}
+		if e.Note != "" {
+			notes = append(notes, e.Note)
 		}
 	}
+	end, duration := times[1], ""
+	switch {
+	case open && d.past:
+		end = style.Render("…")
+	case open:
+		end, duration = style.Render("now  "), timeutils.FormatDuration(time.Since(r.span[0].Time))
+	default:
+		duration = timeutils.FormatDuration(r.span[1].Time.Sub(r.span[0].Time))
+	}
+	str := times[0] + style.Render(" → ") + end + "   " + style.Render(duration)
+	if project := r.span[0].Project; project != "" {
+		str += "  " + helperStyle.Render("["+project+"]")
+	}
+	if r.span[0].Billable {
+		str += " " + helperStyle.Render("$")
+	}
+	if len(notes) > 0 {
+		str += "  " + helperStyle.Render(strings.Join(notes, " / "))
+	}
 
-	fmt.Fprint(w, fn(string(i)))
+	if d.selected/2*2 == r.first {
+		fmt.Fprint(w, selectedItemStyle.Render("> ")+str)
+		return
+	}
+	fmt.Fprint(w, itemStyle.Render(str))
 }
 
 type model struct {
 	list              list.Model
+	selected          int // index of the selected entry
 	textInput         textinput.Model
-	durations         timeutils.Durations
+	entries           tracking.Entries
+	durations         timeutils.Durations // times of the entries
 	total             time.Duration
 	totalProvisionnal time.Duration
 	overtime          time.Duration
@@ -73,49 +215,283 @@ type model struct {
 	percentage        float64
 	quitting          bool
 	progress          progress.Model
+	flashProgress     progress.Model
 	target            time.Duration
 	startupTime       time.Time
+	startupSource     string
+	bootTime          time.Time
+	widgets           *widget.Server
+	idleTimeout       time.Duration
+	flashes           int
+	limits            overtimeThresholds
+	capBanner         bool
+	frozen            bool
+	frozenView        string // screen displayed while frozen
+	pomodoro          pomodoro
+	prompts           []prompt
+	docs              docs
+	showDocs          bool
+	startOnKey        bool
+	store             *tracking.Store // keeps the entries, nil when they are not stored
+	day               time.Time       // day of the entries in the store
+	stored            tracking.Day    // what the store held when last read or written
+	today             *model          // the tracker of today while a past day is shown, nil otherwise
+	balance           balanceLoaded   // flex balance carried over from the days before
+	week              weekLoaded      // figures of the days of the week before today
+	month             monthLoaded     // figures of the days of the month shown by the calendar
+	calendarDay       time.Time       // day selected in the calendar
+	stats             statsLoaded     // figures of the days of the stats view
+	screen            screen          // what is displayed, see NextScreen
+	power             platform.PowerSupply
+	lunch             *timeutils.LunchWindow
+	lunchBreak        lunchBreak
+	lunchReturn       time.Time // scheduled clock in at the end of the lunch break
+	reminder          breakReminder
+	away              time.Time
+	home              *time.Location // time zone the days are counted in, see --home-tz
+	editing           bool
+	annotating        bool
+	editIndex         int
+	settingTarget     bool      // the input changes the target
+	typing            bool      // the input has the focus, even while empty
+	until             time.Time // time to leave at, the target is derived from it when set
+	fullDay           time.Duration
+	projects          []string
+	project           string   // the project new spans are booked on
+	billable          []string // projects billable by default
+	inputs            []string // previous inputs, the most recent first
+	recalled          bool     // a previous input was picked with up/down
+	recall            int      // position from 1 in inputs of the one recalled from an empty input
+	undo              []tracking.Entries
+	redo              []tracking.Entries
+	keys              keyMap
+	help              help.Model
+	showHelp          bool
+	gradient          *headerGradient
+	onQuit            string
+	compact           bool
+	countdown         bool
+	width             int
+	notice            string
+	noticeErr         bool // the notice reports a failure
+	breakBudget       time.Duration
+	plannedBreak      time.Duration // break time included in the planned exit
+	breakProgress     progress.Model
+}
+
+// SetKeys replaces the key bindings.
+func (m model) SetKeys(keys keyMap) model {
+	m.keys = keys
+	return m
 }
 
+// Select selects the entry at index i, moving the list to its span.
+func (m model) Select(i int) model {
+	m.selected = max(min(i, len(m.entries)-1), 0)
+	m.list.SetDelegate(itemDelegate{selected: m.selected, past: m.today != nil})
+	m.list.Select(rowOf(m.selected))
+	return m
+}
+
+// Quit leaves the application, an open span is handled according to onQuit.
+func (m model) Quit() (tea.Model, tea.Cmd) {
+	if m.today != nil {
+		return m.today.Quit()
+	}
+	if len(m.entries)%2 == 1 {
+		switch m.onQuit {
+		case quitClockOut:
+			m = m.Append(m.clock().Truncate(time.Minute))
+		case quitAsk:
+			since := timeutils.FormatTime(m.entries.Last().Time)
+			return m.Ask("a span is open since "+since+", quit anyway?", func(m model) model {
+				m.quitting = true
+				return m
+			}), nil
+		}
+	}
+	m.quitting = true
+	return m, tea.Quit
+}
+
+// Append adds an entry without note at time t.
 func (m model) Append(t time.Time) model {
-	m.durations = m.durations.Append(t)
+	return m.AppendEntry(tracking.Entry{Time: t})
+}
+
+// AppendEntry adds e to the entries and clears the input.
+func (m model) AppendEntry(e tracking.Entry) model {
+	return m.AppendEntries(tracking.Entries{e})
+}
+
+// AppendEntries adds all of entries at once and clears the input. The latest
+// entry is selected, so that the list scrolls along as the day goes.
+func (m model) AppendEntries(entries tracking.Entries) model {
+	all := m.entries
+	for _, e := range entries {
+		if e.Project == "" {
+			e.Project = m.project
+		}
+		e.Billable = e.Billable || m.billableByDefault(e.Project)
+		all = all.Add(e)
+	}
+	m = m.SetEntries(all).Select(len(all) - 1)
+	m.textInput.Reset()
+	m.typing = false
+	return m
+}
+
+// SetEntries replaces all the entries and refreshes the list and figures.
+func (m model) SetEntries(entries tracking.Entries) model {
+	m.entries = entries
+	m.durations = entries.Times()
+
+	m.list.SetItems(rows(m.entries))
+	return m.Select(m.selected).RecalculateDurations()
+}
+
+// Replace replaces the entry at index i with e and keeps it selected.
+func (m model) Replace(i int, e tracking.Entry) model {
+	m = m.SetEntries(m.entries.Replace(i, e))
+	m.textInput.Reset()
+	if j := m.entries.Index(e.Time); j >= 0 {
+		m = m.Select(j)
+	}
+	return m
+}
+
+// Edit loads the selected entry into the input so that it can be corrected.
+func (m model) Edit() model {
+	if len(m.entries) == 0 {
+		return m
+	}
+	m.editing = true
+	m.editIndex = m.selected
+	e := m.entries[m.editIndex]
+	m.textInput.Prompt = "edit> "
+	value := timeutils.FormatTime(e.Time)
+	if !timeutils.SameDay(e.Time, m.clock()) && (m.today == nil || !timeutils.SameDay(e.Time, m.day)) {
+		value = e.Time.Format("2006-01-02 15:04")
+	}
+	m.textInput.SetValue(strings.TrimSpace(value + " " + e.Note))
+	m.textInput.CursorEnd()
+	return m
+}
 
-	items := make([]list.Item, len(m.durations))
-	for i, t := range m.durations.StringSlice() {
-		items[i] = item(t)
+// Annotate loads the note of the selected entry into the input so that it can be changed.
+func (m model) Annotate() model {
+	if len(m.entries) == 0 {
+		return m
 	}
-	m.list.SetItems(items)
+	m.annotating = true
+	m.textInput.ShowSuggestions = false
+	m.editIndex = m.selected
+	m.textInput.Prompt = "note> "
+	m.textInput.SetValue(m.entries[m.editIndex].Note)
+	m.textInput.CursorEnd()
+	return m
+}
+
+// composing reports whether the user is typing in the input, in which case
+// letters go to the input instead of triggering commands.
+func (m model) composing() bool {
+	return m.annotating || m.settingTarget || m.typing || m.textInput.Value() != ""
+}
+
+// StopEditing leaves the edition mode, the input goes back to adding entries.
+func (m model) StopEditing() model {
+	m.editing = false
+	m.annotating = false
+	m.settingTarget = false
+	m.typing = false
+	m.textInput.ShowSuggestions = true
+	m.textInput.Prompt = "> "
 	m.textInput.Reset()
-	m = m.RecalculateDurations()
 	return m
 }
 
 func (m model) RecalculateDurations() model {
-	m.totalProvisionnal = timeutils.SumPairedDurationsWithNow(m.durations, time.Now())
+	if !m.until.IsZero() {
+		m.target = m.untilTarget()
+	}
+	m.totalProvisionnal = timeutils.SumPairedDurationsWithNow(m.durations, m.now())
 	m.total = timeutils.SumPairedDurationsWithNow(m.durations, time.Time{})
 	m.overtime = m.total - m.target
+	// The exit assumes the rest of the break budget is still to be taken, unless it
+	// is the one to leave at
+	m.plannedBreak = 0
+	if m.breakBudget > 0 && m.until.IsZero() {
+		m.plannedBreak = max(m.breakBudget-m.durations.BreakDuration(time.Time{}), 0)
+	}
 	last := m.durations.Last()
 	if !last.IsZero() {
 		remaining := m.target - m.total
-		m.planned = last.Add(remaining).Format("15:04")
+		m.planned = last.Add(remaining + m.plannedBreak).Format("15:04")
 	}
 
 	tmin := m.total.Minutes()
 	ta := m.target.Minutes()
-	if tmin > ta {
+	if ta <= 0 {
+		// The target is still to be set
+		m.percentage = 0
+	} else if tmin > ta {
 		m.percentage = 1
 	} else {
 		m.percentage = ((tmin * 100) / ta) / 100
 	}
+	m.widgets.Publish(m.Status())
 	return m
 }
 
-func initialModel(target time.Duration) model {
+// isUnloggedLunch reports whether an absence spent during an open span looks like
+// a lunch break, while no lunch break was logged yet today.
+func (m model) isUnloggedLunch(gap timeutils.Span) bool {
+	if m.lunch == nil || len(m.durations)%2 == 0 || !gap.Start.After(m.durations.Last()) {
+		return false
+	}
+	for _, b := range m.durations.Breaks() {
+		if m.lunch.IsLunch(b) {
+			return false
+		}
+	}
+	return m.lunch.IsLunch(gap)
+}
+
+// Status returns a snapshot of the current tracking state, as published to desktop widgets.
+func (m model) Status() widget.Status {
+	s := widget.Status{
+		ClockedIn:          len(m.durations)%2 == 1,
+		TotalSeconds:       int64(m.total.Seconds()),
+		ProvisionalSeconds: int64(m.totalProvisionnal.Seconds()),
+		TargetSeconds:      int64(m.target.Seconds()),
+		OvertimeSeconds:    int64(m.overtime.Seconds()),
+		Percentage:         m.percentage,
+		Entries:            m.durations,
+	}
+	if m.power != platform.PowerUnknown {
+		s.PowerSource = m.power.String()
+	}
+	if !m.startupTime.IsZero() {
+		start := m.startupTime
+		s.Start = &start
+		s.StartSource = m.startupSource
+	}
+	if last := m.durations.Last(); !last.IsZero() {
+		exit := last.Add(m.target - m.total)
+		s.PlannedExit = &exit
+	}
+	return s
+}
+
+func initialModel(target time.Duration, idleTimeout time.Duration, overtimeAlert time.Duration, widgets *widget.Server) model {
 	ti := textinput.New()
 	ti.Placeholder = ""
 	ti.Focus()
-	ti.CharLimit = 5
-	ti.Width = 20
+	// Room for a time followed by a short note
+	ti.CharLimit = 64
+	ti.Width = 40
+	// Previous inputs are suggested while typing, see Remember
+	ti.ShowSuggestions = true
 
 	l := list.New([]list.Item{}, itemDelegate{}, defaultWidth, listHeight)
 	l.Title = ""
@@ -124,64 +500,511 @@ func initialModel(target time.Duration) model {
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
-	l.AdditionalFullHelpKeys = func() []key.Binding {
-		return []key.Binding{
-			key.NewBinding(
-				key.WithKeys("x"),
-				key.WithHelp("x", "delete"),
-			),
-		}
-	}
+	// The selection moves by entry rather than by row, everything goes through our key map
+	keys := defaultKeyMap()
+	l.SetShowHelp(false)
+	l.KeyMap = list.KeyMap{}
+
+	pomo, _ := parsePomodoro(defaultPomodoro)
+	lunchBreak, _ := parseLunchBreak(defaultLunchBreak)
+
+	h := help.New()
+	h.Styles.ShortKey = helperStyle
+	h.Styles.ShortDesc = helperStyle
 
 	return model{
 		textInput:         ti,
 		list:              l,
+		entries:           make(tracking.Entries, 0),
 		durations:         make(timeutils.Durations, 0),
 		total:             0,
 		totalProvisionnal: 0,
 		quitting:          false,
-		progress:          progress.New(progress.WithScaledGradient("#FF7CCB", "#FDFF8C")),
+		progress:          progress.New(progress.WithScaledGradient(activeTheme.progress[0], activeTheme.progress[1])),
+		flashProgress:     progress.New(progress.WithSolidFill(activeTheme.flash)),
+		breakProgress:     progress.New(progress.WithSolidFill(activeTheme.breaks), progress.WithoutPercentage(), progress.WithWidth(breakBarWidth)),
 		target:            target,
+		docs:              newDocs(),
+		keys:              keys,
+		help:              h,
+		widgets:           widgets,
+		idleTimeout:       idleTimeout,
+		limits:            overtimeThresholds{Alert: overtimeAlert},
+		pomodoro:          pomo,
+		lunchBreak:        lunchBreak,
+		fullDay:           defaultFullDay,
+		home:              time.Local,
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return textinput.Blink
+	if m.store != nil {
+		return tea.Batch(textinput.Blink, tickMinute(), loadBalance(m.day), loadWeek(m.day, m.clock()))
+	}
+	return tea.Batch(textinput.Blink, tickMinute())
+}
+
+// clock returns the current time in the home time zone, see --home-tz.
+func (m model) clock() time.Time {
+	if m.home == nil {
+		return time.Now()
+	}
+	return time.Now().In(m.home)
+}
+
+// tickMinute fires at the start of every minute of the system clock.
+func tickMinute() tea.Cmd {
+	return tea.Every(time.Minute, func(t time.Time) tea.Msg { return minuteTick(t) })
+}
+
+// targetReached reports whether the time worked, including the open span, reached the target.
+func (m model) targetReached() bool {
+	return m.target > 0 && m.totalProvisionnal >= m.target
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// While a past day is shown, today goes on being tracked in the background
+	if m.today != nil {
+		switch msg.(type) {
+		case tea.KeyMsg, copied, weekLoaded, monthLoaded, statsLoaded:
+		case tea.WindowSizeMsg:
+			m, _ = m.updateToday(msg)
+		default:
+			return m.updateToday(msg)
+		}
+	}
+
+	before := m.entries
+	updated, cmd := m.update(msg)
+	after, ok := updated.(model)
+	if !ok {
+		return updated, cmd
+	}
+	// Another day is shown, its entries have nothing to do with the ones before.
+	// Back on today, the balance and the week follow the corrections of the past days.
+	if !after.day.Equal(m.day) {
+		if after.today == nil {
+			cmd = tea.Batch(cmd, loadBalance(after.day), loadWeek(after.day, after.clock()))
+		}
+		return after, cmd
+	}
+
+	// Every change of the entries can be undone, except undo and redo themselves
+	// which manage the history on their own
+	historyOp := len(after.undo) != len(m.undo) || len(after.redo) != len(m.redo)
+	if !historyOp && !before.Equal(after.entries) {
+		after = after.record(before)
+	}
+	if !before.Equal(after.entries) || after.target != m.target {
+		after = after.save()
+	}
+	if after.today != nil {
+		return after, cmd
+	}
+
+	// Alert the user when a threshold has just been crossed
+	cmds := []tea.Cmd{cmd}
+	if !m.targetReached() && after.targetReached() {
+		after.flashes = flashCount
+		cmds = append(cmds, tickFlash(), bell,
+			notify(tr("Daily target reached"), trf("You worked %s, enjoy your day !", timeutils.FormatDuration(after.totalProvisionnal))))
+	}
+	after, alerts := after.overtimeCrossed(m.overtimeLevel(), after.overtimeLevel())
+	cmds = append(cmds, alerts...)
+	return after, tea.Batch(cmds...)
+}
+
+func tickFlash() tea.Cmd {
+	return tea.Tick(flashInterval, func(time.Time) tea.Msg { return flashTick{} })
+}
+
+// bell rings the terminal bell.
+func bell() tea.Msg {
+	_, _ = terminal.WriteString("\a")
+	return nil
+}
+
+// terminal is the output of the user interface. The escape sequences sent besides
+// the views, e.g. the bell, are written through it so that they never end up in the
+// middle of a frame.
+var terminal = &lockedOutput{File: os.Stdout}
+
+// lockedOutput serializes the writes to a terminal. It is still the terminal for the
+// program, which reads its size and sets its mode.
+type lockedOutput struct {
+	mu sync.Mutex
+	*os.File
+}
+
+func (o *lockedOutput) Write(b []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.File.Write(b)
+}
+
+func (o *lockedOutput) WriteString(s string) (int, error) {
+	return o.Write([]byte(s))
+}
+
+// notify displays a desktop notification in the background, failures are ignored.
+func notify(title, body string) tea.Cmd {
+	return func() tea.Msg {
+		_ = platform.Notify(title, body)
+		return nil
+	}
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case minuteTick:
+		var remind tea.Cmd
+		m, remind = m.updateLunch(time.Time(msg)).RecalculateDurations().remindBreak(time.Time(msg))
+		return m, tea.Batch(tickMinute(), remind)
+
+	case flashTick:
+		if m.flashes > 0 {
+			m.flashes--
+		}
+		if m.flashes > 0 {
+			return m, tickFlash()
+		}
+		return m, nil
+
+	case pomodoroTick:
+		return m.updatePomodoro(msg)
+
+	case copied:
+		m.notice, m.noticeErr = tr("summary copied to the clipboard"), false
+		if msg.err != nil && msg.sent {
+			m.notice, m.noticeErr = trf("summary sent to the terminal, the clipboard tool failed: %s", msg.err), true
+		} else if msg.err != nil {
+			m.notice, m.noticeErr = trf("copy failed: %s", msg.err), true
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
+		m.width = msg.Width
 		m.list.SetWidth(msg.Width)
 		m.progress.Width = msg.Width - padding*2 - 4
 		if m.progress.Width > maxWidth {
 			m.progress.Width = maxWidth
 		}
+		m.flashProgress.Width = m.progress.Width
+		m.docs = m.docs.SetSize(msg.Width, msg.Height)
+		m.help.Width = msg.Width
+		return m, nil
+
+	case balanceLoaded:
+		m.balance = msg
+		return m, nil
+
+	case weekLoaded:
+		m.week = msg
+		return m, nil
+
+	case monthLoaded:
+		m.month = msg
+		return m, nil
+
+	case statsLoaded:
+		m.stats = msg
+		return m, nil
+
+	case systemBootTime:
+		m.bootTime = time.Time(msg)
 		return m, nil
 
-	case systemStartupTime:
-		m.startupTime = time.Time(msg)
+	case systemStartup:
+		m.startupTime = msg.time
+		m.startupSource = msg.source
 		if len(m.durations) == 0 {
 			return m.Append(m.startupTime), nil
 		}
+		m.widgets.Publish(m.Status())
+
+	case systemResumed:
+		// The time spent suspended (e.g. lid closed over lunch) is not worked time:
+		// close the open span when the system went to sleep and reopen it on wake up.
+		if len(m.durations)%2 == 1 && msg.Suspended.After(m.durations.Last()) {
+			m = m.Append(msg.Suspended)
+			return m.Append(msg.Resumed), nil
+		}
+		return m, nil
+
+	case entriesStored:
+		m.stored = tracking.Day(msg)
+		if !m.stored.Entries.Equal(m.entries) {
+			return m.SetEntries(m.stored.Entries), nil
+		}
+		return m, nil
+
+	case awaitInteraction:
+		m.startOnKey = len(m.durations) == 0
+		return m, nil
+
+	case systemWakes:
+		// Offer the first wake up of the day as start when nothing earlier was recorded
+		if len(msg) == 0 {
+			return m, nil
+		}
+		woke := msg[0].Truncate(time.Minute)
+		if len(m.durations) > 0 && !woke.Before(m.durations[0]) {
+			return m, nil
+		}
+		return m.Ask("machine woke at "+timeutils.FormatTime(woke)+", add as start?", func(m model) model {
+			// Replace the detected startup time, the wake up is more accurate
+			if len(m.durations) > 0 && m.durations[0].Equal(m.startupTime) {
+				m = m.SetEntries(m.entries.Remove(0))
+			}
+			return m.Append(woke)
+		}), nil
+
+	case powerChanged:
+		m.power = msg.Source
+		m.widgets.Publish(m.Status())
+		// People undocking when leaving the office likely forgot to clock out
+		if msg.Source == platform.PowerBattery && len(m.durations)%2 == 1 {
+			at := msg.At.Truncate(time.Minute)
+			return m.Ask("switched to battery at "+timeutils.FormatTime(at)+", clock out?", func(m model) model {
+				return m.Append(at)
+			}), nil
+		}
+		return m, nil
+
+	case userIdle:
+		idle := time.Duration(msg)
+		now := m.clock()
+		if idle >= awayThreshold {
+			if m.away.IsZero() {
+				m.away = now.Add(-idle)
+			}
+		} else if !m.away.IsZero() {
+			gap := timeutils.Span{Start: m.away, End: now.Add(-idle)}
+			m.away = time.Time{}
+			if m.isUnloggedLunch(gap) {
+				m = m.Append(gap.Start.Truncate(time.Minute))
+				return m.Append(gap.End.Truncate(time.Minute)), nil
+			}
+		}
+
+		// Clock out at the moment the user left once the idle threshold is crossed
+		if m.idleTimeout > 0 && idle >= m.idleTimeout && len(m.durations)%2 == 1 {
+			left := now.Add(-idle)
+			if left.After(m.durations.Last()) {
+				return m.Append(left), nil
+			}
+		}
+		return m, nil
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "q", "ctrl+c":
+		// Notices only last until the next key press
+		m.notice = ""
+		if m.startOnKey {
+			m.startOnKey = false
+			if len(m.durations) == 0 {
+				m.startupTime = m.clock().Truncate(time.Minute)
+				m.startupSource = "first interaction"
+				m = m.Append(m.startupTime)
+			}
+		}
+		if m.frozen {
+			switch {
+			case key.Matches(msg, m.keys.ForceQuit):
+				m.quitting = true
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Freeze, m.keys.Cancel):
+				return m.Resume(), nil
+			}
+			return m, nil
+		}
+		if m.showDocs {
+			switch {
+			case key.Matches(msg, m.keys.Cancel, m.keys.Docs, m.keys.Quit):
+				m.showDocs = false
+				return m, nil
+			case key.Matches(msg, m.keys.ForceQuit):
+				m.quitting = true
+				return m, tea.Quit
+			}
+			var cmd tea.Cmd
+			m.docs, cmd = m.docs.Update(msg)
+			return m, cmd
+		}
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, m.keys.ForceQuit):
+				m.quitting = true
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Cancel, m.keys.Help, m.keys.Quit):
+				m.showHelp = false
+			}
+			return m, nil
+		}
+		if m.capBanner {
+			switch {
+			case key.Matches(msg, m.keys.ForceQuit):
+				m.quitting = true
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Now):
+				m.capBanner = false
+				if len(m.entries)%2 == 1 {
+					m = m.Append(m.clock().Truncate(time.Minute))
+				}
+			case key.Matches(msg, m.keys.Add, m.keys.Cancel):
+				m.capBanner = false
+			}
+			return m, nil
+		}
+		if m.screen != trackerScreen {
+			return m.updateScreen(msg)
+		}
+		if len(m.prompts) > 0 && !m.settingTarget {
+			return m.answerPrompt(msg)
+		}
+		switch {
+		case m.recalling() && key.Matches(msg, m.textInput.KeyMap.PrevSuggestion):
+			return m.Recall(1), nil
+		case m.recalling() && key.Matches(msg, m.textInput.KeyMap.NextSuggestion):
+			return m.Recall(-1), nil
+		case m.composing() && !key.Matches(msg, m.keys.Add, m.keys.Cancel, m.keys.ForceQuit):
+			// Typed into the input below, commands only apply to an empty input
+			m.recalled = key.Matches(msg, m.textInput.KeyMap.NextSuggestion, m.textInput.KeyMap.PrevSuggestion)
+		case key.Matches(msg, m.keys.ForceQuit):
 			m.quitting = true
 			return m, tea.Quit
-		case "enter":
-			t, err := timeutils.ParseTime(m.textInput.Value())
+		case key.Matches(msg, m.keys.Quit):
+			return m.Quit()
+		case key.Matches(msg, m.keys.View):
+			return m.NextScreen()
+		case key.Matches(msg, m.keys.Add):
+			if m.annotating {
+				i := m.editIndex
+				note := m.textInput.Value()
+				m = m.StopEditing()
+				return m.SetEntries(m.entries.Annotate(i, note)), nil
+			}
+			if m.settingTarget {
+				value := m.textInput.Value()
+				target, until, err := parseTarget(value, m.fullDay)
+				if err != nil {
+					m.notice, m.noticeErr = err.Error(), true
+					return m, nil
+				}
+				// The target of a past day is not the one to start with next time
+				if m.today == nil {
+					saveTarget(value)
+				}
+				return m.StopEditing().SetTarget(target, until), nil
+			}
+			// A previous input picked with up/down is entered as is, otherwise the
+			// suggestion is only completed with tab
+			value := m.textInput.Value()
+			if suggestion := m.textInput.CurrentSuggestion(); m.recalled && suggestion != "" {
+				value = suggestion
+			}
+			m.recalled = false
+			if m.editing {
+				e, err := tracking.ParseEntry(value, m.clock())
+				if err != nil {
+					// The input is kept so that it can be corrected
+					m.notice, m.noticeErr = err.Error(), true
+					return m, nil
+				}
+				i := m.editIndex
+				e = m.onShownDay(e)
+				e.Project, e.Billable = m.entries[i].Project, m.entries[i].Billable
+				return m.Remember(value).StopEditing().Replace(i, e), nil
+			}
+			entries, err := tracking.ParseEntries(value, m.clock())
 			if err != nil {
-				m.textInput.Reset()
+				m.notice, m.noticeErr = err.Error(), true
 				return m, nil
 			}
-			return m.Append(t), nil
-		case "x":
-			m.list.RemoveItem(m.list.Index())
-			m.durations = m.durations.RemoveItem(m.list.Index())
-			m = m.RecalculateDurations()
+			for i, e := range entries {
+				entries[i] = m.onShownDay(e)
+			}
+			return m.Remember(value).AppendEntries(entries), nil
+		case key.Matches(msg, m.keys.Type):
+			// The keys typed from then on go to the input, e.g. "today 17:30"
+			m.typing = true
+			return m, nil
+		case key.Matches(msg, m.keys.Edit):
+			return m.Edit(), nil
+		case key.Matches(msg, m.keys.Note):
+			return m.Annotate(), nil
+		case key.Matches(msg, m.keys.Cancel):
+			if m.settingTarget && m.target <= 0 && m.today == nil {
+				// Nothing to track without a target
+				m.quitting = true
+				return m, tea.Quit
+			}
+			if m.editing || m.annotating || m.settingTarget || m.typing {
+				return m.StopEditing(), nil
+			}
+			if m.textInput.Value() == "" && m.breakDue(m.clock()) {
+				return m.DismissReminder(), nil
+			}
+			if m.textInput.Value() == "" && m.today != nil {
+				return *m.today, nil
+			}
+			m.textInput.Reset()
+			return m, nil
+		case key.Matches(msg, m.keys.Up):
+			return m.Select(m.selected - 1), nil
+		case key.Matches(msg, m.keys.Down):
+			return m.Select(m.selected + 1), nil
+		case key.Matches(msg, m.keys.First):
+			return m.Select(0), nil
+		case key.Matches(msg, m.keys.Last):
+			return m.Select(len(m.entries) - 1), nil
+		case key.Matches(msg, m.keys.PrevDay):
+			return m.ShowDay(m.day.AddDate(0, 0, -1)), nil
+		case key.Matches(msg, m.keys.NextDay):
+			return m.ShowDay(m.day.AddDate(0, 0, 1)), nil
+		case m.today != nil && key.Matches(msg, m.keys.Now, m.keys.Lunch, m.keys.Project, m.keys.Pomodoro, m.keys.Countdown, m.keys.Freeze):
+			m.notice, m.noticeErr = tr("only today can be clocked"), true
+			return m, nil
+		case key.Matches(msg, m.keys.Delete):
+			return m.SetEntries(m.entries.Remove(m.selected)), nil
+		case key.Matches(msg, m.keys.Undo):
+			return m.Undo(), nil
+		case key.Matches(msg, m.keys.Redo):
+			return m.Redo(), nil
+		case key.Matches(msg, m.keys.Now):
+			return m.Append(m.clock().Truncate(time.Minute)), nil
+		case key.Matches(msg, m.keys.Docs):
+			m.showDocs = true
+			return m, nil
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = true
+			return m, nil
+		case key.Matches(msg, m.keys.Copy):
+			return m, copyToClipboard(m.summaryLine())
+		case key.Matches(msg, m.keys.CopyLines):
+			return m, copyToClipboard(m.summaryLines())
+		case key.Matches(msg, m.keys.Pomodoro):
+			return m.TogglePomodoro()
+		case key.Matches(msg, m.keys.Compact):
+			m.compact = !m.compact
 			return m, nil
+		case key.Matches(msg, m.keys.Project):
+			return m.SwitchProject(m.clock().Truncate(time.Minute)), nil
+		case key.Matches(msg, m.keys.Billable):
+			return m.SetEntries(m.entries.ToggleBillable(m.selected)), nil
+		case key.Matches(msg, m.keys.Lunch):
+			lunch, err := m.TakeLunch(m.clock().Truncate(time.Minute))
+			if err != nil {
+				m.notice, m.noticeErr = err.Error(), true
+				return m, nil
+			}
+			return lunch, nil
+		case key.Matches(msg, m.keys.Target):
+			return m.AskTarget(m.targetText()), nil
+		case key.Matches(msg, m.keys.Countdown):
+			m.countdown = !m.countdown
+			return m, nil
+		case key.Matches(msg, m.keys.Freeze):
+			return m.Freeze(), nil
 		}
 	}
 
@@ -200,7 +1023,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) View() string {
 	if m.quitting {
-		return quitTextStyle.Render("Enjoy your day !")
+		return platform.TaskbarProgress(platform.TaskbarNone, 0) + quitTextStyle.Render(tr("Enjoy your day !"))
+	}
+	if m.frozen {
+		return m.frozenScreen()
+	}
+
+	if m.showDocs {
+		return m.docs.View()
+	}
+	if m.showHelp {
+		return m.helpView()
+	}
+	if m.capBanner {
+		return m.capBannerView()
+	}
+	if m.settingTarget && m.target <= 0 {
+		return m.targetView()
+	}
+	if m.screen != trackerScreen {
+		return m.screenView()
+	}
+
+	taskbar := platform.TaskbarNormal
+	if m.flashes%2 == 1 {
+		taskbar = platform.TaskbarPaused
 	}
 
 	style := reachedStyle
@@ -208,45 +1055,501 @@ func (m model) View() string {
 		style = unreachedStyle
 	}
 
-	return style.Render(timeutils.FormatDuration(m.total)) +
-		helperStyle.Render(" / "+timeutils.FormatDuration(m.target)) +
-		helperStyle.Render(" • previsional ") + reachedStyle.Render(timeutils.FormatDuration(m.totalProvisionnal)) +
-		helperStyle.Render(" • start ") + reachedStyle.Render(timeutils.FormatTime(m.startupTime)) +
-		helperStyle.Render(" • exit ") + reachedStyle.Render(m.planned) +
-		helperStyle.Render(" • overtime ") + reachedStyle.Render(timeutils.FormatDuration(m.overtime)) +
+	if m.compact {
+		return platform.TaskbarProgress(taskbar, m.percentage) + m.pastView() + m.compactView(style)
+	}
+
+	return platform.TaskbarProgress(taskbar, m.percentage) +
+		m.pastView() +
+		m.headerView(style) +
 		"\n" +
+		m.promptView() +
 		m.textInput.View() +
 		"\n" +
-		m.list.View() +
+		m.noticeView() +
+		m.reminderView() +
+		m.entriesView() +
+		"\n" +
+		m.timelineView(m.progress.Width) +
+		"\n" +
+		m.projectsView() +
+		m.metadataView() +
+		m.progressView() +
 		"\n" +
-		m.progress.ViewAs(m.percentage)
+		m.breakView() +
+		m.help.ShortHelpView(m.keys.ShortHelp())
+}
+
+// noticeView renders the feedback of the last action, if any.
+func (m model) noticeView() string {
+	if m.notice == "" {
+		return ""
+	}
+	if m.noticeErr {
+		return errorStyle.Render(m.notice) + "\n"
+	}
+	return helperStyle.Render(m.notice) + "\n"
+}
+
+// entriesView renders the list of entries, or the countdown in its place.
+func (m model) entriesView() string {
+	if m.countdown {
+		return lipgloss.NewStyle().Width(max(m.progress.Width, defaultWidth)).Height(listHeight).
+			Align(lipgloss.Center).Render(m.countdownView())
+	}
+	return m.list.View()
+}
+
+// compactView collapses the tracker into a single status line, the input only
+// shows up below it while typing or answering a question.
+func (m model) compactView(total lipgloss.Style) string {
+	line := m.clockedView() + " " +
+		total.Render(timeutils.FormatDuration(m.total)) +
+		helperStyle.Render("/"+timeutils.FormatDuration(m.target)) +
+		helperStyle.Render(" "+tr("exit")+" ") + m.accentStyle().Render(m.planned) + " "
+
+	bar := m.progress
+	if m.flashes%2 == 1 {
+		bar = m.flashProgress
+	}
+	bar.Width = m.width - lipgloss.Width(line)
+	if bar.Width > maxWidth {
+		bar.Width = maxWidth
+	}
+	if bar.Width > 10 {
+		line += bar.ViewAs(m.percentage)
+	}
+
+	if len(m.prompts) > 0 || m.composing() || m.editing {
+		line += "\n" + m.promptView() + m.textInput.View()
+	}
+	if m.notice != "" {
+		line += "\n" + strings.TrimSuffix(m.noticeView(), "\n")
+	}
+	if reminder := m.reminderView(); reminder != "" {
+		line += "\n" + strings.TrimSuffix(reminder, "\n")
+	}
+	return line
+}
+
+// clockedView renders whether a span is currently open.
+func (m model) clockedView() string {
+	if len(m.entries)%2 == 1 {
+		return clockedInStyle.Render("IN")
+	}
+	return clockedOutStyle.Render("OUT")
+}
+
+// accentStyle returns the style of the header values, following the progression of the day when a gradient is set.
+func (m model) accentStyle() lipgloss.Style {
+	if m.gradient == nil || m.target <= 0 {
+		return reachedStyle
+	}
+	return reachedStyle.Foreground(m.gradient.Color(m.totalProvisionnal.Minutes() / m.target.Minutes()))
+}
+
+// helpView renders the full screen help overlay.
+func (m model) helpView() string {
+	return docHeadingStyle.Render("KEYBINDINGS") + "\n\n" +
+		m.help.FullHelpView(m.keys.FullHelp()) + "\n\n" +
+		helperStyle.Render("y/n answer questions displayed above the input • "+
+			m.keys.Help.Help().Key+" or "+m.keys.Cancel.Help().Key+" to close")
+}
+
+// progressView renders the progress bar, flashing once the target is reached.
+// With a maximum working time, the bar goes up to the maximum and markers show
+// where the target and the maximum stand.
+func (m model) progressView() string {
+	bar := m.progress
+	if m.flashes%2 == 1 {
+		bar = m.flashProgress
+	}
+	if m.limits.Cap <= m.target {
+		return bar.ViewAs(m.percentage)
+	}
+
+	percent := fmt.Sprintf(" %3.0f%%", m.percentage*100)
+	bar.ShowPercentage = false
+	bar.Width -= len(percent)
+	return bar.ViewAs(min(m.total.Minutes()/m.limits.Cap.Minutes(), 1)) + percent + "\n" +
+		helperStyle.Render(m.markersView(bar.Width))
+}
+
+// markersView renders, below a bar of the given width, a marker for the target
+// under its position and a marker for the maximum at the end of the bar.
+func (m model) markersView(width int) string {
+	minimum := "min " + timeutils.FormatDuration(m.target) + " ▲"
+	maximum := "max " + timeutils.FormatDuration(m.limits.Cap) + " ▲"
+	if width < 2 {
+		return ""
+	}
+	at := int(float64(width-1) * m.target.Minutes() / m.limits.Cap.Minutes())
+	// Labels are left out when there is no room for them
+	if at+1 < len([]rune(minimum)) || width-1-at <= len([]rune(maximum)) {
+		minimum, maximum = "▲", "▲"
+	}
+	line := strings.Repeat(" ", at+1-len([]rune(minimum))) + minimum
+	return line + strings.Repeat(" ", width-len([]rune(line))-len([]rune(maximum))) + maximum
+}
+
+// breakView renders the break time taken against the break budget, if any.
+func (m model) breakView() string {
+	if m.breakBudget <= 0 {
+		return ""
+	}
+	taken := m.durations.BreakDuration(m.now())
+	view := helperStyle.Render(tr("break")+" ") +
+		m.breakProgress.ViewAs(min(taken.Minutes()/m.breakBudget.Minutes(), 1)) +
+		helperStyle.Render(" "+timeutils.FormatDuration(taken)+" / "+timeutils.FormatDuration(m.breakBudget))
+	if owed := m.breakBudget - taken; owed > 0 && len(m.durations) > 0 {
+		view += helperStyle.Render(" • ") + m.accentStyle().Render(timeutils.FormatDuration(owed)) + helperStyle.Render(" "+tr("still owed"))
+	}
+	return view + "\n"
+}
+
+// startupSourceView renders which provider detected the startup time, if known.
+func (m model) startupSourceView() string {
+	if m.startupSource == "" {
+		return ""
+	}
+	return helperStyle.Render(" (" + m.startupSource + ")")
 }
 
 func main() {
+	idleTimeout := flag.Duration("idle-timeout", 0, "automatically clock out after being idle for this long (e.g. 15m), 0 disables")
+	overtimeWarn := flag.Duration("overtime-warn", 0, "color the overtime and notify when it goes beyond this duration (e.g. 1h), 0 disables")
+	overtimeAlert := flag.Duration("overtime-alert", 0, "notify when overtime goes beyond this duration (e.g. 2h), 0 disables")
+	maxWorked := flag.Duration("max-worked", 0, "maximum time worked in a day (e.g. 10h), notifies once reached, 0 disables")
+	maxWorkedBanner := flag.Bool("max-worked-banner", false, "block the tracker behind a banner once --max-worked is reached")
+	start := flag.String("start", "", "override the detected startup time (HH:MM), defaults to the "+platform.StartupEnv+" environment variable")
+	listen := flag.String("listen", "", "serve the live status over HTTP on this address (e.g. 127.0.0.1:4242), see /status and /events")
+	lunchWindow := flag.String("lunch-window", "", "absences of 30 to 90 minutes within this window, e.g. 11:30-14:00, are recorded as lunch break")
+	lunchConfidence := flag.Float64("lunch-confidence", timeutils.DefaultLunchWindow.Threshold, "share of an absence which must fall within the lunch window, between 0 and 1")
+	homeTZ := flag.String("home-tz", "", "travel mode: compute and display times in this time zone (e.g. Europe/Zurich) instead of the system one")
+	headerColors := flag.String("header-colors", "", "accent colors of the header at the start of the day, at the planned exit and in overtime, defaults to the theme ones, none disables")
+	lang := flag.String("lang", "", "language of the user interface: "+strings.Join(languages, ", ")+", defaults to the one of the locale")
+	themeName := flag.String("theme", "", "color theme: "+strings.Join(themeNames(), ", ")+", defaults to mono when NO_COLOR is set and dark otherwise")
+	breakBudget := flag.Duration("break-budget", 0, "break time expected during the day (e.g. 1h), shown as a second progress bar, 0 hides it")
+	pomodoroCycle := flag.String("pomodoro", defaultPomodoro, "work/break durations of the pomodoro timer started with p")
+	projects := flag.String("projects", "", "comma separated projects to book the time on, switched with s, e.g. \"acme,internal\"")
+	billable := flag.String("billable", "", "comma separated projects whose spans are billable by default, * for all, b toggles the selected span")
+	targetFlag := flag.String("target", "", "default daily target, e.g. 8:00, 80% or \"until 17:00\", for the days left out of --work-week")
+	fullDay := flag.Duration("full-day", defaultFullDay, "length of a full-time day, targets given as a percentage (e.g. 80%) are a share of it")
+	workWeek := flag.String("work-week", "", "target of each day of the week, as a share of a full day or a duration, e.g. \"mon=100% wed=50% fri=6:00 sat=0\", used when none is given, 0 for days off")
+	breakReminderAfter := flag.Duration("break-reminder", 0, "suggest a pause after working this long without a break (e.g. 4h), 0 disables")
+	breakReminderNotify := flag.Bool("break-reminder-notify", false, "also display a desktop notification when --break-reminder suggests a pause")
+	lunchBreakFlag := flag.String("lunch-break", defaultLunchBreak, "lunch break taken with l, as a duration (e.g. 45m) or the time work resumes at (e.g. 13:00)")
+	keyOverrides := flag.String("keys", "", "rebind actions to other keys, e.g. \"quit=ctrl+q now=n,space\", see the Keybindings page")
+	compact := flag.Bool("compact", false, "start in compact mode, a single status line for tiny panes")
+	var plain bool
+	flag.BoolVar(&plain, "plain", false, "print plain status lines instead of the full screen user interface and read the input line by line, for screen readers and dumb terminals")
+	flag.BoolVar(&plain, "no-tui", false, "same as --plain")
+	onQuit := flag.String("on-quit", quitAsk, "what to do when quitting with an open span: ask, clock-out or quit")
+	format := flag.String("format", "", "print the status of the day in this format and exit: "+strings.Join(statusFormats, ", ")+", see timely status")
+	dataDir := flag.String("data-dir", "", "directory in which timely keeps its data, defaults to the "+platform.DataDirEnv+" environment variable or the one of the platform")
+	defaultConfig, _ := configPath()
+	config := flag.String("config", defaultConfig, "configuration file holding the defaults of the flags, written by a setup on the first launch")
+	flag.Usage = usage
+	flag.Parse()
+
+	// The configuration file holds the defaults of the flags, it is written by a
+	// setup run on the first launch. Settings are taken from the environment, then
+	// the file, then the command line, each overriding the previous one.
+	stdin := bufio.NewScanner(os.Stdin)
+	cmd, isCommand := findCommand(flag.Arg(0))
+	if *config != "" {
+		if _, err := os.Stat(*config); errors.Is(err, os.ErrNotExist) && !isCommand && *format == "" && isTerminal(os.Stdin) {
+			if !setUp(*config, plain, stdin) {
+				os.Exit(0)
+			}
+		}
+		settings, err := loadConfig(*config)
+		if err == nil {
+			err = applyConfig(flag.CommandLine, settings)
+		}
+		if err != nil {
+			fmt.Println("Invalid configuration:", err)
+			os.Exit(1)
+		}
+	}
+	// The environment only defaults what neither the command line nor the file set
+	if err := applyConfig(flag.CommandLine, envConfig()); err != nil {
+		fmt.Println("Invalid environment:", err)
+		os.Exit(1)
+	}
+	if *dataDir != "" {
+		os.Setenv(platform.DataDirEnv, expandHome(*dataDir))
+	}
 
-	if len(os.Args) < 2 {
-		fmt.Println("Please provide a target time in HH:MM format as an argument.")
+	selected, err := selectLanguage(*lang)
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
+	language = selected
 
-	targetTime, err := timeutils.ParseTime(os.Args[1])
+	t, err := selectTheme(*themeName)
 	if err != nil {
-		fmt.Println("Unknown target time", os.Args[1])
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	t.apply()
+
+	// The days are counted in the home time zone, see homeLocation
+	if *homeTZ != "" {
+		if _, err := time.LoadLocation(*homeTZ); err != nil {
+			fmt.Println("Unknown time zone", *homeTZ)
+			os.Exit(1)
+		}
 	}
-	target := time.Duration(targetTime.Hour())*time.Hour + time.Duration(targetTime.Minute())*time.Minute
+	home := homeLocation()
 
-	p := tea.NewProgram(initialModel(target), tea.WithAltScreen())
+	if isCommand {
+		os.Exit(cmd.run(flag.Args()[1:]))
+	}
+	if *format != "" {
+		os.Exit(printStatus(*format, ""))
+	}
+
+	week, err := parseWorkWeek(*workWeek, *fullDay)
+	if err != nil {
+		fmt.Println("Invalid work week:", err)
+		os.Exit(1)
+	}
+
+	// Without argument, the target is the one of the day, or it is asked for once the
+	// tracker is up
+	var target time.Duration
+	var until time.Time
+	text := strings.Join(flag.Args(), " ")
+	if text != "" {
+		target, until, err = parseTarget(text, *fullDay)
+		if err != nil {
+			fmt.Println("Unknown target time:", err)
+			os.Exit(1)
+		}
+		saveTarget(text)
+	} else if target, until, err = todayTarget(week, *targetFlag, *fullDay, homeNow()); err != nil {
+		fmt.Println("Unknown target time:", err)
+		os.Exit(1)
+	}
+
+	startup := platform.DefaultStartupChain()
+	if *start != "" {
+		startTime, err := timeutils.ParseTimeOn(*start, homeNow())
+		if err != nil {
+			fmt.Println("Unknown start time", *start)
+			os.Exit(1)
+		}
+		startup = platform.StartupChain{platform.FixedStartupProvider(startTime)}
+	}
+
+	// Desktop widgets are a nice to have, the TUI works without them
+	widgets, err := widget.Listen(widget.SocketPath())
+	if err == nil {
+		defer widgets.Close()
+	}
+
+	if *listen != "" {
+		if widgets == nil {
+			fmt.Println("Cannot serve the status over HTTP:", err)
+			os.Exit(1)
+		}
+		l, err := net.Listen("tcp", *listen)
+		if err != nil {
+			fmt.Println("Cannot serve the status over HTTP:", err)
+			os.Exit(1)
+		}
+		go http.Serve(l, widgets.Handler())
+	}
+
+	m := initialModel(target, *idleTimeout, *overtimeAlert, widgets)
+	// The entries of the day are kept, so that timely can be closed and reopened
+	store, err := dayStore()
+	today := homeNow()
+	if err == nil {
+		day, err := store.Load(today)
+		if err != nil {
+			fmt.Println("Cannot read the entries of the day:", err)
+			os.Exit(1)
+		}
+		m = m.SetStore(store, today, day)
+		// Reopened, the day goes on with the target it was tracked with: it wins over
+		// the configured ones, only a target given as argument changes it
+		if text == "" && day.Target > 0 {
+			target, until = day.Target, time.Time{}
+			m = m.SetTarget(target, time.Time{})
+		}
+	}
+	m.power, _ = platform.PowerSource()
+	m.home = home
+	m.compact = *compact
+	m.fullDay = *fullDay
+	m.billable = parseProjects(*billable)
+	if m.projects = parseProjects(*projects); len(m.projects) > 0 {
+		m.project = m.projects[0]
+	}
+	m = m.SetInputHistory(loadInputHistory())
+	if !until.IsZero() {
+		m = m.SetTarget(0, until)
+	}
+	if target <= 0 && until.IsZero() {
+		m = m.AskTarget(lastTarget())
+		if h, ok := holidayOn(today); ok {
+			m.notice = holidayNotice(h)
+		} else if dayOff(week, today) {
+			m.notice = tr("today is a day off in the work week")
+		}
+	}
+	m.pomodoro, err = parsePomodoro(*pomodoroCycle)
+	if err != nil {
+		fmt.Println("Invalid pomodoro cycle:", err)
+		os.Exit(1)
+	}
+	m.reminder = breakReminder{After: *breakReminderAfter, Notify: *breakReminderNotify}
+	m.lunchBreak, err = parseLunchBreak(*lunchBreakFlag)
+	if err != nil {
+		fmt.Println("Invalid lunch break:", err)
+		os.Exit(1)
+	}
+	m.limits.Warn = *overtimeWarn
+	m.limits.Cap = *maxWorked
+	m.limits.Banner = *maxWorkedBanner
+	overrides, err := parseKeyOverrides(*keyOverrides)
+	if err == nil {
+		var keys keyMap
+		keys, err = m.keys.Rebind(overrides)
+		m = m.SetKeys(keys)
+	}
+	if err != nil {
+		fmt.Println("Invalid keys:", err)
+		os.Exit(1)
+	}
+	m.breakBudget = *breakBudget
+	if *lunchWindow != "" {
+		lunch, err := timeutils.ParseLunchWindow(*lunchWindow)
+		if err != nil {
+			fmt.Println("Invalid lunch window:", err)
+			os.Exit(1)
+		}
+		lunch.Threshold = *lunchConfidence
+		m.lunch = &lunch
+	}
+	switch *onQuit {
+	case quitAsk, quitClockOut, quitAnyway:
+		m.onQuit = *onQuit
+	default:
+		fmt.Println("Unknown --on-quit value", *onQuit)
+		os.Exit(1)
+	}
+	if *headerColors == "" {
+		*headerColors = activeTheme.header
+	}
+	m.gradient, err = parseHeaderGradient(*headerColors)
+	if err != nil {
+		fmt.Println("Invalid header colors:", err)
+		os.Exit(1)
+	}
+	var p *tea.Program
+	if plain {
+		for _, line := range plainChanges(model{}, m) {
+			fmt.Println(line)
+		}
+		p = tea.NewProgram(plainModel{model: m, out: terminal}, tea.WithoutRenderer(), tea.WithInput(nil), tea.WithOutput(terminal))
+		go readPlainInput(p, stdin)
+	} else {
+		p = tea.NewProgram(m, tea.WithAltScreen(), tea.WithOutput(terminal))
+	}
+
+	// The times reported by the system are shown in the home time zone
+	go func() {
+		// Under WSL the uptime is the one of the VM hosting the distribution
+		var boot time.Time
+		if uptime, err := platform.Uptime(); err == nil && !platform.IsWSL() {
+			boot = homeNow().Add(-uptime)
+			p.Send(systemBootTime(boot))
+		}
+
+		up, source, err := startup.Detect()
+
+		// Cross-check the detected startup time with the uptime: nobody can start
+		// working on a machine before it booted. Fall back to the boot time when
+		// detection failed or is obviously wrong, provided the machine booted today.
+		// An explicit override is always trusted.
+		if source != platform.StartupSourceOverride && !boot.IsZero() && timeutils.SameDay(boot, homeNow()) &&
+			(err != nil || up.Before(boot.Add(-time.Minute))) {
+			up, source, err = boot.Truncate(time.Minute), "uptime", nil
+		}
+		if err != nil {
+			// Under WSL the boot time is meaningless, the day starts with the first key press
+			if platform.IsWSL() {
+				p.Send(awaitInteraction{})
+			}
+			return
+		}
+		up = up.In(home)
+		if source == platform.StartupSourceOverride {
+			// Given as a time of day, which is one of the home time zone
+			up = timeutils.OnDay(up, homeNow())
+		}
+		p.Send(systemStartup{time: up, source: source})
+	}()
+
+	if m.store != nil {
+		go func() {
+			for day := range m.store.Watch(today, storeCheckInterval) {
+				p.Send(entriesStored(day))
+			}
+		}()
+	}
+
+	go func() {
+		for ev := range platform.WatchSuspend(suspendCheckInterval) {
+			ev.Suspended, ev.Resumed = ev.Suspended.In(home), ev.Resumed.In(home)
+			p.Send(systemResumed(ev))
+		}
+	}()
 
 	go func() {
-		up, err := platform.Startup()
+		wakes, err := platform.ResumeTimes(timeutils.StartOfDay(homeNow()))
 		if err != nil {
 			return
 		}
-		p.Send(systemStartupTime(up))
+		for i, t := range wakes {
+			wakes[i] = t.In(home)
+		}
+		p.Send(systemWakes(wakes))
+	}()
+
+	go func() {
+		for change := range platform.WatchPowerSource(powerCheckInterval) {
+			change.At = change.At.In(home)
+			p.Send(powerChanged(change))
+		}
 	}()
 
-	if _, err := p.Run(); err != nil {
+	if *idleTimeout > 0 || m.lunch != nil {
+		go func() {
+			for range time.Tick(idleCheckInterval) {
+				idle, err := platform.IdleTime()
+				if err != nil {
+					return
+				}
+				p.Send(userIdle(idle))
+			}
+		}()
+	}
+
+	final, err := p.Run()
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	if p, ok := final.(plainModel); ok {
+		final = p.model
+	}
+	// Printed once the alternate screen is left, so that it stays in the terminal
+	if m, ok := final.(model); ok {
+		fmt.Print(m.quitSummary())
+	}
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// typeKeys sends each rune of text to m as a key press.
+func typeKeys(m model, text string) model {
+	for _, r := range text {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(model)
+	}
+	return m
+}
+
+func TestModel_Type(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	m := initialModel(8*time.Hour, 0, 0, nil)
+
+	// Typed at once, the t of today changes the target
+	if typed := typeKeys(m, "t"); !typed.settingTarget {
+		t.Fatal("t does not change the target of an empty input")
+	}
+
+	m = typeKeys(m, "itoday 17:30")
+	if m.settingTarget || m.textInput.Value() != "today 17:30" {
+		t.Fatalf("typed %q, setting the target %t, want \"today 17:30\"", m.textInput.Value(), m.settingTarget)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	want := tracking.Entries{{Time: timeutils.StartOfDay(time.Now()).Add(17*time.Hour + 30*time.Minute)}}
+	if !m.entries.Equal(want) {
+		t.Errorf("entered %v, want %v", m.entries, want)
+	}
+	if m.typing || m.composing() {
+		t.Error("the input keeps the focus once the entry is added")
+	}
+
+	// esc gives the keys back to the commands
+	m = typeKeys(m, "i")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(model); m.composing() {
+		t.Error("the input keeps the focus after esc")
+	}
+}
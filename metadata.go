@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// runMeta implements the meta command and returns the process exit code.
+func runMeta(args []string) int {
+	fs := flag.NewFlagSet("meta", flag.ExitOnError)
+	date := fs.String("date", "", "day of the metadata: today, yesterday or YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely meta [--date DAY] [key=value ...]")
+		fmt.Fprintln(fs.Output(), "Attaches key-value pairs to a stored day, e.g. mood=good ticket=ABC-12, an empty value")
+		fmt.Fprintln(fs.Output(), "removing the key. The metadata of the day is then printed, one key=value per line.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	changes, err := parseMetadata(fs.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	day := homeNow()
+	if *date != "" {
+		if day, err = timeutils.ParseDate(*date, day); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the metadata:", err)
+		return 1
+	}
+	stored, err := store.Load(day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot read the day:", err)
+		return 1
+	}
+	if len(changes) > 0 {
+		stored.Metadata = withMetadata(stored.Metadata, changes)
+		if err := store.Save(day, stored); err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot store the metadata:", err)
+			return 1
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(stored.Metadata)) {
+		fmt.Println(key + "=" + stored.Metadata[key])
+	}
+	return 0
+}
+
+// parseMetadata parses key=value arguments, keys hold no space and an empty value
+// removes the key, see withMetadata.
+func parseMetadata(args []string) (map[string]string, error) {
+	changes := make(map[string]string)
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("expected key=value, got %q", arg)
+		}
+		changes[key] = strings.TrimSpace(value)
+	}
+	return changes, nil
+}
+
+// withMetadata returns a copy of metadata with the values of changes, the keys of
+// empty values being removed. It is nil once no key is left.
+func withMetadata(metadata, changes map[string]string) map[string]string {
+	updated := maps.Clone(metadata)
+	if updated == nil {
+		updated = make(map[string]string)
+	}
+	for key, value := range changes {
+		if value == "" {
+			delete(updated, key)
+		} else {
+			updated[key] = value
+		}
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+	return updated
+}
+
+// metadataView renders the metadata of the day shown, sorted by key.
+func (m model) metadataView() string {
+	if len(m.stored.Metadata) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, key := range slices.Sorted(maps.Keys(m.stored.Metadata)) {
+		parts = append(parts, helperStyle.Render(key+" ")+m.accentStyle().Render(m.stored.Metadata[key]))
+	}
+	return strings.Join(parts, helperStyle.Render(" • ")) + "\n"
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{
+		{"none", nil, map[string]string{}, ""},
+		{"pairs", []string{"mood=good", "ticket=ABC-12"}, map[string]string{"mood": "good", "ticket": "ABC-12"}, ""},
+		{"value with spaces and equal signs", []string{"standup=fixed a=b "}, map[string]string{"standup": "fixed a=b"}, ""},
+		{"removal", []string{"mood="}, map[string]string{"mood": ""}, ""},
+		{"missing equal sign", []string{"mood"}, nil, `expected key=value, got "mood"`},
+		{"missing key", []string{"=good"}, nil, `expected key=value, got "=good"`},
+		{"key with a space", []string{"my mood=good"}, nil, `expected key=value, got "my mood=good"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMetadata(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseMetadata() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMetadata() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("parseMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		changes  map[string]string
+		want     map[string]string
+	}{
+		{"added", nil, map[string]string{"mood": "good"}, map[string]string{"mood": "good"}},
+		{"replaced", map[string]string{"mood": "good", "ticket": "ABC-12"}, map[string]string{"mood": "tired"},
+			map[string]string{"mood": "tired", "ticket": "ABC-12"}},
+		{"removed", map[string]string{"mood": "good", "ticket": "ABC-12"}, map[string]string{"ticket": ""}, map[string]string{"mood": "good"}},
+		{"last one removed", map[string]string{"mood": "good"}, map[string]string{"mood": ""}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := maps.Clone(tt.metadata)
+			got := withMetadata(tt.metadata, tt.changes)
+			if !maps.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("withMetadata() = %v, want %v", got, tt.want)
+			}
+			if !maps.Equal(tt.metadata, before) {
+				t.Errorf("withMetadata() changed the metadata to %v", tt.metadata)
+			}
+		})
+	}
+}
+
+func TestRunMeta(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := tracking.Entries{{Time: day.Add(8 * time.Hour)}}
+	if err := store.Save(day, tracking.Day{Entries: entries}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The steps run in turn on the same day
+	steps := []struct {
+		args []string
+		want int
+		// wantMetadata is the stored metadata after the step
+		wantMetadata map[string]string
+	}{
+		{[]string{"--date", "2025-03-14", "mood=good", "ticket=ABC-12"}, 0, map[string]string{"mood": "good", "ticket": "ABC-12"}},
+		{[]string{"--date", "2025-03-14"}, 0, map[string]string{"mood": "good", "ticket": "ABC-12"}},
+		{[]string{"--date", "2025-03-14", "ticket="}, 0, map[string]string{"mood": "good"}},
+		{[]string{"--date", "2025-03-14", "mood"}, 2, map[string]string{"mood": "good"}},
+		{[]string{"--date", "14.03.2025", "mood=tired"}, 2, map[string]string{"mood": "good"}},
+	}
+	for _, step := range steps {
+		if got := runMeta(step.args); got != step.want {
+			t.Errorf("runMeta(%q) = %d, want %d", step.args, got, step.want)
+		}
+		stored, err := store.Load(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !maps.Equal(stored.Metadata, step.wantMetadata) || !stored.Entries.Equal(entries) {
+			t.Errorf("after runMeta(%q) stored %v, %v, want %v, %v", step.args, stored.Entries, stored.Metadata, entries, step.wantMetadata)
+		}
+	}
+
+	// The tracker keeps the metadata attached while it runs
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, day, tracking.Day{Entries: entries})
+	m = m.Append(day.Add(12 * time.Hour)).save()
+	if stored, _ := store.Load(day); !maps.Equal(stored.Metadata, map[string]string{"mood": "good"}) {
+		t.Errorf("the tracker stored the metadata %v, want mood=good", stored.Metadata)
+	}
+	if view := m.metadataView(); !strings.Contains(view, "mood") || !strings.Contains(view, "good") {
+		t.Errorf("metadataView() = %q, want the mood", view)
+	}
+}
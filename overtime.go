@@ -0,0 +1,86 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// overtimeLevel grades how far the day goes beyond the target.
+type overtimeLevel int
+
+const (
+	overtimeNone overtimeLevel = iota
+	// overtimeWarning is reached past the warning threshold
+	overtimeWarning
+	// overtimeAlert is reached past the alert threshold
+	overtimeAlert
+	// overtimeCap is reached once the maximum working time is worked
+	overtimeCap
+)
+
+// overtimeThresholds configures the overtime levels, zero durations disable the level.
+type overtimeThresholds struct {
+	// Warn and Alert are durations past the target
+	Warn  time.Duration
+	Alert time.Duration
+	// Cap is the maximum time worked in a day, e.g. a legal limit
+	Cap time.Duration
+	// Banner blocks the tracker behind a banner once the cap is reached
+	Banner bool
+}
+
+// overtimeLevel returns the highest level reached, including the open span.
+func (m model) overtimeLevel() overtimeLevel {
+	overtime := m.totalProvisionnal - m.target
+	switch {
+	case m.limits.Cap > 0 && m.totalProvisionnal >= m.limits.Cap:
+		return overtimeCap
+	case m.limits.Alert > 0 && overtime > m.limits.Alert:
+		return overtimeAlert
+	case m.limits.Warn > 0 && overtime > m.limits.Warn:
+		return overtimeWarning
+	}
+	return overtimeNone
+}
+
+// overtimeCrossed returns the alerts due when the overtime level goes from before to after.
+func (m model) overtimeCrossed(before, after overtimeLevel) (model, []tea.Cmd) {
+	if after <= before {
+		return m, nil
+	}
+	overtime := timeutils.FormatDuration(m.totalProvisionnal - m.target)
+	switch after {
+	case overtimeWarning:
+		return m, []tea.Cmd{notify(tr("Overtime warning"), trf("You are %s past your daily target", overtime))}
+	case overtimeAlert:
+		return m, []tea.Cmd{notify(tr("Overtime"), trf("You are %s past your daily target", overtime))}
+	case overtimeCap:
+		m.capBanner = m.limits.Banner
+		return m, []tea.Cmd{bell, notify(tr("Maximum working time reached"),
+			trf("You worked %s, the maximum is %s", timeutils.FormatDuration(m.totalProvisionnal), timeutils.FormatDuration(m.limits.Cap)))}
+	}
+	return m, nil
+}
+
+// overtimeStyle returns the style of the overtime figure for the current level.
+func (m model) overtimeStyle() lipgloss.Style {
+	switch m.overtimeLevel() {
+	case overtimeWarning:
+		return warningStyle
+	case overtimeAlert, overtimeCap:
+		return unreachedStyle
+	}
+	return m.accentStyle()
+}
+
+// capBannerView renders the banner blocking the tracker once the maximum working time is reached.
+func (m model) capBannerView() string {
+	return bannerStyle.Render(
+		"Maximum working time reached\n\n"+
+			"You worked "+timeutils.FormatDuration(m.totalProvisionnal)+
+			", the maximum is "+timeutils.FormatDuration(m.limits.Cap)+".") +
+		"\n\n" + helperStyle.Render(m.keys.Now.Help().Key+" clock out now • "+m.keys.Add.Help().Key+" or "+m.keys.Cancel.Help().Key+" dismiss")
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// ShowDay shows the stored entries of day so that they can be viewed and corrected.
+// Today goes on being tracked in the background meanwhile, and is shown again for
+// a day from today on.
+func (m model) ShowDay(day time.Time) model {
+	today := m
+	if m.today != nil {
+		today = *m.today
+	}
+	if today.store == nil {
+		m.notice, m.noticeErr = "the entries of other days are not stored", true
+		return m
+	}
+	if !timeutils.StartOfDay(day).Before(timeutils.StartOfDay(today.day)) {
+		return today
+	}
+	stored, err := today.store.Load(day)
+	if err != nil {
+		m.notice, m.noticeErr = "cannot read the entries: "+err.Error(), true
+		return m
+	}
+	target, err := pastTarget(day, stored)
+	if err != nil {
+		m.notice, m.noticeErr = err.Error(), true
+		return m
+	}
+
+	past := today.StopEditing()
+	past.today = &today
+	// Nothing happening now applies to a past day
+	past.widgets = nil
+	past.undo, past.redo, past.prompts = nil, nil, nil
+	past.until, past.lunchReturn, past.away = time.Time{}, time.Time{}, time.Time{}
+	past.reminder = breakReminder{}
+	past.startOnKey, past.capBanner, past.countdown, past.flashes = false, false, false, 0
+	past.notice = ""
+	past.target = target
+	return past.SetStore(*today.store, day, stored)
+}
+
+// pastTarget returns the target of a past day: the one it was tracked with, or the
+// one of the options, as in the reports.
+func pastTarget(day time.Time, stored tracking.Day) (time.Duration, error) {
+	if stored.Target > 0 {
+		return stored.Target, nil
+	}
+	fullDay, err := time.ParseDuration(flag.Lookup("full-day").Value.String())
+	if err != nil {
+		return 0, err
+	}
+	week, err := parseWorkWeek(flag.Lookup("work-week").Value.String(), fullDay)
+	if err != nil {
+		return 0, err
+	}
+	target, _, err := todayTarget(week, flag.Lookup("target").Value.String(), fullDay, day)
+	return target, err
+}
+
+// updateToday passes msg to the tracker of today, running in the background while a
+// past day is shown.
+func (m model) updateToday(msg tea.Msg) (model, tea.Cmd) {
+	updated, cmd := m.today.Update(msg)
+	if today, ok := updated.(model); ok {
+		m.today = &today
+	}
+	return m, cmd
+}
+
+// onShownDay moves an entry typed without date onto the day shown, when it is a past one.
+func (m model) onShownDay(e tracking.Entry) tracking.Entry {
+	if m.today != nil && timeutils.SameDay(e.Time, m.clock()) {
+		e.Time = timeutils.OnDay(e.Time, m.day)
+	}
+	return e
+}
+
+// now returns the current time, or the zero time on a past day: its open span, if
+// any, is left out as in the reports.
+func (m model) now() time.Time {
+	if m.today != nil {
+		return time.Time{}
+	}
+	return m.clock()
+}
+
+// pastView marks that a past day is shown, with the keys leading back to today.
+func (m model) pastView() string {
+	if m.today == nil {
+		return ""
+	}
+	return warningStyle.Render("◀ "+trf("past day: %s", formatDate(m.day, "Mon 2 Jan 2006"))) +
+		helperStyle.Render(" "+trf("%s/%s other days • %s back to today", m.keys.PrevDay.Help().Key, m.keys.NextDay.Help().Key,
+			m.keys.Cancel.Help().Key)) + "\n"
+}
+
+// pastMetrics lists the figures of the header which apply to a past day.
+func (m model) pastMetrics() []headerMetric {
+	accent := m.accentStyle()
+	metrics := []headerMetric{
+		{"", "", helperStyle.Render(formatDate(m.day, "Mon 2 Jan")+" ") + accent.Render(fmt.Sprintf("W%02d", timeutils.WeekOf(m.day).Number))},
+		{tr("overtime"), tr("over"), m.overtimeStyle().Render(timeutils.FormatDuration(m.overtime))},
+	}
+	if m.billing() {
+		metrics = append(metrics, headerMetric{tr("billable"), tr("bill"), m.billableView()})
+	}
+	return metrics
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestModel_ShowDay(t *testing.T) {
+	today := timeutils.StartOfDay(time.Now()).Add(8 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	store := tracking.Store{Dir: t.TempDir()}
+	past := tracking.Day{Entries: tracking.Entries{{Time: yesterday}, {Time: yesterday.Add(time.Hour)}}, Target: 8 * time.Hour}
+	if err := store.Save(yesterday, past); err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, today, tracking.Day{Entries: tracking.Entries{{Time: today}}})
+	press := func(m model, k string) model {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return updated.(model)
+	}
+
+	m = press(m, "[")
+	if m.today == nil || !m.entries.Equal(past.Entries) || m.total != time.Hour {
+		t.Fatalf("previous day shows %v, total %s, want %v", m.entries, m.total, past.Entries)
+	}
+	// Corrections are stored on the day shown, today is left as it is
+	m = press(m, "x")
+	if stored, _ := store.Load(yesterday); !stored.Entries.Equal(past.Entries[:1]) {
+		t.Errorf("previous day stored %v after a deletion, want %v", stored.Entries, past.Entries[:1])
+	}
+	if stored, _ := store.Load(today); len(stored.Entries) != 0 {
+		t.Errorf("today stored %v, want nothing", stored.Entries)
+	}
+	// Today goes on being tracked in the background
+	added := tracking.Day{Entries: tracking.Entries{{Time: today}, {Time: today.Add(time.Minute)}}}
+	if err := store.Save(today, added); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := m.Update(entriesStored(added))
+	m = updated.(model)
+	if !m.entries.Equal(past.Entries[:1]) || len(m.today.entries) != 2 {
+		t.Errorf("past day shows %v and today %v after a change of today", m.entries, m.today.entries)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(model)
+	if m.today != nil || len(m.entries) != 2 {
+		t.Errorf("esc shows %v, want today's entries", m.entries)
+	}
+}
@@ -0,0 +1,119 @@
+// Package holidays keeps the public holidays and vacation days, on which no work is
+// expected: they come from country presets, iCalendar files or are added one by one.
+package holidays
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// dateLayout is the layout of the dates of a holiday.
+const dateLayout = "2006-01-02"
+
+// Holiday is a day off, a public holiday or a vacation day.
+type Holiday struct {
+	Date     string `json:"date"` // 2006-01-02
+	Name     string `json:"name,omitempty"`
+	Vacation bool   `json:"vacation,omitempty"`
+}
+
+// Day returns the date of the holiday, at midnight in the local time zone.
+func (h Holiday) Day() time.Time {
+	day, _ := time.ParseInLocation(dateLayout, h.Date, time.Local)
+	return day
+}
+
+// New returns the holiday of the day of t.
+func New(t time.Time, name string, vacation bool) Holiday {
+	return Holiday{Date: t.Format(dateLayout), Name: name, Vacation: vacation}
+}
+
+// Holidays is a collection of holidays, in chronological order and one per day.
+type Holidays []Holiday
+
+// On returns the holiday of the day of t, if any.
+func (hs Holidays) On(t time.Time) (Holiday, bool) {
+	i, found := slices.BinarySearchFunc(hs, t.Format(dateLayout), func(h Holiday, date string) int {
+		return compareDates(h.Date, date)
+	})
+	if !found {
+		return Holiday{}, false
+	}
+	return hs[i], true
+}
+
+// Add returns a copy of the collection with the holidays added, replacing the ones
+// of the same days.
+func (hs Holidays) Add(added ...Holiday) Holidays {
+	merged := slices.Clone(hs)
+	for _, h := range added {
+		i, found := slices.BinarySearchFunc(merged, h.Date, func(h Holiday, date string) int {
+			return compareDates(h.Date, date)
+		})
+		if found {
+			merged[i] = h
+		} else {
+			merged = slices.Insert(merged, i, h)
+		}
+	}
+	return merged
+}
+
+// Remove returns a copy of the collection without the holiday of the day of t.
+func (hs Holidays) Remove(t time.Time) Holidays {
+	date := t.Format(dateLayout)
+	return slices.DeleteFunc(slices.Clone(hs), func(h Holiday) bool { return h.Date == date })
+}
+
+// compareDates compares dates written as 2006-01-02, which sort as strings.
+func compareDates(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Load reads the holidays kept in the JSON file at path, none when it is missing.
+func Load(path string) (Holidays, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var hs Holidays
+	if err := json.Unmarshal(b, &hs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, h := range hs {
+		if _, err := time.Parse(dateLayout, h.Date); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	// Sorted, in case the file was edited by hand
+	return Holidays{}.Add(hs...), nil
+}
+
+// Save writes the holidays to the JSON file at path, creating its directory.
+func Save(path string, hs Holidays) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if hs == nil {
+		hs = Holidays{}
+	}
+	b, err := json.MarshalIndent(hs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
@@ -0,0 +1,70 @@
+package holidays
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func day(date string) time.Time {
+	t, _ := time.ParseInLocation(dateLayout, date, time.Local)
+	return t
+}
+
+func dates(hs Holidays) []string {
+	var ds []string
+	for _, h := range hs {
+		ds = append(ds, h.Date)
+	}
+	return ds
+}
+
+func TestHolidays_AddOnRemove(t *testing.T) {
+	hs := Holidays{}.Add(
+		New(day("2025-12-25"), "Christmas", false),
+		New(day("2025-08-01"), "National Day", false),
+		New(day("2025-07-14"), "", true),
+	)
+	if got, want := dates(hs), []string{"2025-07-14", "2025-08-01", "2025-12-25"}; !slices.Equal(got, want) {
+		t.Fatalf("Add() = %v, want %v", got, want)
+	}
+
+	// Adding a day again replaces it
+	hs = hs.Add(New(day("2025-07-14"), "summer", true))
+	if h, ok := hs.On(day("2025-07-14").Add(15 * time.Hour)); !ok || h.Name != "summer" || !h.Vacation {
+		t.Errorf("On() = %v, %v, want the summer vacation", h, ok)
+	}
+	if len(hs) != 3 {
+		t.Errorf("Add() of an existing day = %v, want 3 holidays", dates(hs))
+	}
+	if _, ok := hs.On(day("2025-07-15")); ok {
+		t.Error("On() of a working day found a holiday")
+	}
+
+	removed := hs.Remove(day("2025-08-01"))
+	if got, want := dates(removed), []string{"2025-07-14", "2025-12-25"}; !slices.Equal(got, want) {
+		t.Errorf("Remove() = %v, want %v", got, want)
+	}
+	if len(hs) != 3 {
+		t.Errorf("Remove() modified the receiver: %v", dates(hs))
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	if hs, err := Load(path); err != nil || len(hs) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v, want none", hs, err)
+	}
+	saved := Holidays{}.Add(New(day("2025-12-25"), "Christmas", false), New(day("2025-07-14"), "", true))
+	if err := Save(path, saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !slices.Equal(loaded, saved) {
+		t.Errorf("Load() = %v, want %v", loaded, saved)
+	}
+}
@@ -0,0 +1,93 @@
+package holidays
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ParseICS reads the events of an iCalendar file, e.g. a calendar of public holidays,
+// as holidays. An event lasting several days gives a holiday per day.
+func ParseICS(r io.Reader, vacation bool) (Holidays, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var hs Holidays
+	var start, end time.Time
+	var summary string
+	inEvent := false
+	for n, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Parameters follow the name: DTSTART;VALUE=DATE
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, end, summary = true, time.Time{}, time.Time{}, ""
+		case !inEvent:
+		case name == "DTSTART" || name == "DTEND":
+			day, err := parseICSDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			if name == "DTSTART" {
+				start = day
+			} else {
+				end = day
+			}
+		case name == "SUMMARY":
+			summary = unescape(value)
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			// The end is exclusive, and may be left out for a single day
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				hs = hs.Add(New(day, summary, vacation))
+			}
+		}
+	}
+	return hs, nil
+}
+
+// unfold returns the content lines of an iCalendar file, joining the continuation
+// lines which start with a space or a tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseICSDate parses the date of a DTSTART or DTEND value, either a date (20251225)
+// or a date and time (20251225T000000Z) of which only the date is kept.
+func parseICSDate(s string) (time.Time, error) {
+	date, _, _ := strings.Cut(s, "T")
+	day, err := time.ParseInLocation("20060102", date, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q: not a date", s)
+	}
+	return day, nil
+}
+
+// unescape unescapes a text value of iCalendar.
+func unescape(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
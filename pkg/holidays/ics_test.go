@@ -0,0 +1,47 @@
+package holidays
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20251225",
+		"SUMMARY:Christmas",
+		"  Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250714",
+		"DTEND;VALUE=DATE:20250717",
+		"SUMMARY:Summer\\, at last",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20250801T000000Z",
+		"SUMMARY:National Day",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	hs, err := ParseICS(strings.NewReader(ics), true)
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+	want := []string{"2025-07-14", "2025-07-15", "2025-07-16", "2025-08-01", "2025-12-25"}
+	if got := dates(hs); !slices.Equal(got, want) {
+		t.Fatalf("ParseICS() = %v, want %v", got, want)
+	}
+	if h, _ := hs.On(day("2025-12-25")); h.Name != "Christmas Day" || !h.Vacation {
+		t.Errorf("ParseICS() folded event = %+v, want Christmas Day", h)
+	}
+	if h, _ := hs.On(day("2025-07-15")); h.Name != "Summer, at last" {
+		t.Errorf("ParseICS() escaped summary = %q, want %q", h.Name, "Summer, at last")
+	}
+
+	if _, err := ParseICS(strings.NewReader("BEGIN:VEVENT\nDTSTART:2025\nEND:VEVENT"), false); err == nil {
+		t.Error("ParseICS() of an invalid date succeeded, want an error")
+	}
+}
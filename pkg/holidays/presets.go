@@ -0,0 +1,101 @@
+package holidays
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// presetDay is a public holiday of a preset: a fixed date, or a day relative to Easter
+// Sunday when month is zero.
+type presetDay struct {
+	month      time.Month
+	day        int
+	fromEaster int
+	name       string
+}
+
+// presets lists the public holidays observed nationwide, or in most regions, of each country.
+var presets = map[string][]presetDay{
+	"ch": {
+		{month: time.January, day: 1, name: "New Year's Day"},
+		{fromEaster: -2, name: "Good Friday"},
+		{fromEaster: 1, name: "Easter Monday"},
+		{fromEaster: 39, name: "Ascension Day"},
+		{fromEaster: 50, name: "Whit Monday"},
+		{month: time.August, day: 1, name: "Swiss National Day"},
+		{month: time.December, day: 25, name: "Christmas Day"},
+		{month: time.December, day: 26, name: "St. Stephen's Day"},
+	},
+	"de": {
+		{month: time.January, day: 1, name: "Neujahr"},
+		{fromEaster: -2, name: "Karfreitag"},
+		{fromEaster: 1, name: "Ostermontag"},
+		{month: time.May, day: 1, name: "Tag der Arbeit"},
+		{fromEaster: 39, name: "Christi Himmelfahrt"},
+		{fromEaster: 50, name: "Pfingstmontag"},
+		{month: time.October, day: 3, name: "Tag der Deutschen Einheit"},
+		{month: time.December, day: 25, name: "1. Weihnachtstag"},
+		{month: time.December, day: 26, name: "2. Weihnachtstag"},
+	},
+	"fr": {
+		{month: time.January, day: 1, name: "Jour de l'an"},
+		{fromEaster: 1, name: "Lundi de Pâques"},
+		{month: time.May, day: 1, name: "Fête du Travail"},
+		{month: time.May, day: 8, name: "Victoire 1945"},
+		{fromEaster: 39, name: "Ascension"},
+		{fromEaster: 50, name: "Lundi de Pentecôte"},
+		{month: time.July, day: 14, name: "Fête nationale"},
+		{month: time.August, day: 15, name: "Assomption"},
+		{month: time.November, day: 1, name: "Toussaint"},
+		{month: time.November, day: 11, name: "Armistice 1918"},
+		{month: time.December, day: 25, name: "Noël"},
+	},
+}
+
+// Countries returns the countries with a preset, as ISO 3166 codes.
+func Countries() []string {
+	countries := make([]string, 0, len(presets))
+	for c := range presets {
+		countries = append(countries, c)
+	}
+	slices.Sort(countries)
+	return countries
+}
+
+// Preset returns the public holidays of country during year.
+func Preset(country string, year int) (Holidays, error) {
+	days, ok := presets[strings.ToLower(country)]
+	if !ok {
+		return nil, fmt.Errorf("no holidays known for %q, available countries: %s", country, strings.Join(Countries(), ", "))
+	}
+	easter := Easter(year)
+	var hs Holidays
+	for _, d := range days {
+		day := easter.AddDate(0, 0, d.fromEaster)
+		if d.month != 0 {
+			day = time.Date(year, d.month, d.day, 0, 0, 0, 0, time.Local)
+		}
+		hs = hs.Add(New(day, d.name, false))
+	}
+	return hs, nil
+}
+
+// Easter returns Easter Sunday of year in the Gregorian calendar, at midnight in the
+// local time zone.
+func Easter(year int) time.Time {
+	// Anonymous Gregorian algorithm
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
+}
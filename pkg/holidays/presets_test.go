@@ -0,0 +1,53 @@
+package holidays
+
+import (
+	"testing"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want string
+	}{
+		{2000, "2000-04-23"},
+		{2019, "2019-04-21"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2026, "2026-04-05"},
+		{2038, "2038-04-25"},
+	}
+	for _, tt := range tests {
+		if got := Easter(tt.year).Format(dateLayout); got != tt.want {
+			t.Errorf("Easter(%d) = %s, want %s", tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestPreset(t *testing.T) {
+	tests := []struct {
+		country string
+		date    string
+		want    string
+	}{
+		{"ch", "2025-05-29", "Ascension Day"},
+		{"CH", "2025-08-01", "Swiss National Day"},
+		{"fr", "2025-06-09", "Lundi de Pentecôte"},
+		{"de", "2025-04-18", "Karfreitag"},
+		{"de", "2025-10-03", "Tag der Deutschen Einheit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.date, func(t *testing.T) {
+			hs, err := Preset(tt.country, 2025)
+			if err != nil {
+				t.Fatalf("Preset() error = %v", err)
+			}
+			if h, ok := hs.On(day(tt.date)); !ok || h.Name != tt.want {
+				t.Errorf("Preset() on %s = %v, %v, want %s", tt.date, h, ok, tt.want)
+			}
+		})
+	}
+
+	if _, err := Preset("xx", 2025); err == nil {
+		t.Error("Preset() of an unknown country succeeded, want an error")
+	}
+}
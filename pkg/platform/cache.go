@@ -0,0 +1,113 @@
+package platform
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// startupCacheFile is the name of the file, within the data directory, caching the detected startup time.
+const startupCacheFile = "startup.json"
+
+// bootTolerance absorbs the imprecision of boot times derived from the uptime.
+const bootTolerance = time.Minute
+
+// startupCache is the content of the startup cache file.
+type startupCache struct {
+	Boot    time.Time `json:"boot,omitzero"`
+	Startup time.Time `json:"startup"`
+	Source  string    `json:"source"`
+}
+
+// cachedStartupProvider serves the result of a chain from a cache file, see CachedStartupProvider.
+type cachedStartupProvider struct {
+	path  string
+	chain StartupChain
+	boot  func() (time.Time, error)
+}
+
+// CachedStartupProvider returns a provider serving the startup time detected by chain
+// from the cache file at path. The chain only runs, and the cache is only refreshed,
+// when the cached time is not from today or was detected during a previous boot.
+// Reading or writing the cache is best effort: any failure falls back to the chain.
+func CachedStartupProvider(path string, chain StartupChain) StartupProvider {
+	return cachedStartupProvider{path: path, chain: chain, boot: BootTime}
+}
+
+// DefaultStartupCachePath returns the path of the startup cache in the data directory.
+func DefaultStartupCachePath() (string, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, startupCacheFile), nil
+}
+
+func (c cachedStartupProvider) Name() string { return "cache" }
+
+func (c cachedStartupProvider) Startup() (time.Time, error) {
+	t, _, err := c.Detect()
+	return t, err
+}
+
+// Detect returns the cached startup time and the name of the provider which originally
+// detected it, running the chain on a cache miss.
+func (c cachedStartupProvider) Detect() (time.Time, string, error) {
+	boot, bootErr := c.boot()
+	if bootErr != nil {
+		boot = time.Time{}
+	}
+	if cached, err := readStartupCache(c.path); err == nil && cached.valid(boot, time.Now()) {
+		return cached.Startup, cached.Source, nil
+	}
+
+	t, source, err := c.chain.Detect()
+	if err != nil {
+		return t, source, err
+	}
+	_ = writeStartupCache(c.path, startupCache{Boot: boot, Startup: t, Source: source})
+	return t, source, nil
+}
+
+// valid reports whether the cached value can be used today, during the boot started at boot.
+// A zero boot time means it is unknown, the cache is then only checked to be from today.
+func (s startupCache) valid(boot, now time.Time) bool {
+	if !timeutils.SameDay(s.Startup, now) {
+		return false
+	}
+	if boot.IsZero() || s.Boot.IsZero() {
+		return true
+	}
+	d := boot.Sub(s.Boot)
+	return d < bootTolerance && d > -bootTolerance
+}
+
+func readStartupCache(path string) (startupCache, error) {
+	var s startupCache
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+func writeStartupCache(path string, s startupCache) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// BootTime returns when the system booted, derived from its uptime.
+func BootTime() (time.Time, error) {
+	uptime, err := Uptime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-uptime), nil
+}
@@ -0,0 +1,123 @@
+package platform
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingProvider counts how many times the detection actually ran.
+func countingProvider(name string, t time.Time, calls *int) StartupProvider {
+	return NewStartupProvider(name, func() (time.Time, error) {
+		*calls++
+		return t, nil
+	})
+}
+
+func TestCachedStartupProvider_ServesSameBoot(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	boot := now.Add(-time.Hour)
+	calls := 0
+	c := cachedStartupProvider{
+		path:  filepath.Join(t.TempDir(), startupCacheFile),
+		chain: StartupChain{countingProvider("journal", now, &calls)},
+		boot:  func() (time.Time, error) { return boot, nil },
+	}
+
+	for i := 0; i < 3; i++ {
+		got, source, err := c.Detect()
+		if err != nil {
+			t.Fatalf("Detect() returned error: %v", err)
+		}
+		if !got.Equal(now) || source != "journal" {
+			t.Fatalf("Detect() = %v from %q, want %v from \"journal\"", got, source, now)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("chain ran %d times, want 1", calls)
+	}
+
+	// Uptime based boot times drift by a few seconds between launches
+	c.boot = func() (time.Time, error) { return boot.Add(2 * time.Second), nil }
+	if _, _, _ = c.Detect(); calls != 1 {
+		t.Fatalf("chain ran %d times after a small drift, want 1", calls)
+	}
+
+	// A reboot invalidates the cache
+	c.boot = func() (time.Time, error) { return now, nil }
+	if _, _, _ = c.Detect(); calls != 2 {
+		t.Fatalf("chain ran %d times after a reboot, want 2", calls)
+	}
+}
+
+func TestCachedStartupProvider_UnknownBoot(t *testing.T) {
+	now := time.Now()
+	calls := 0
+	c := cachedStartupProvider{
+		path:  filepath.Join(t.TempDir(), startupCacheFile),
+		chain: StartupChain{countingProvider("who", now, &calls)},
+		boot:  func() (time.Time, error) { return time.Time{}, errors.New("no uptime") },
+	}
+	c.Detect()
+	c.Detect()
+	if calls != 1 {
+		t.Fatalf("chain ran %d times, want 1", calls)
+	}
+}
+
+func TestCachedStartupProvider_Failure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), startupCacheFile)
+	c := cachedStartupProvider{
+		path:  path,
+		chain: StartupChain{fixedProvider("broken", time.Time{}, errors.New("boom"))},
+		boot:  func() (time.Time, error) { return time.Now(), nil },
+	}
+	if _, _, err := c.Detect(); err == nil {
+		t.Fatal("expected the chain error")
+	}
+	if _, err := readStartupCache(path); err == nil {
+		t.Fatal("a failed detection should not be cached")
+	}
+}
+
+func TestStartupChain_ReportsWrappedSource(t *testing.T) {
+	now := time.Now()
+	calls := 0
+	chain := StartupChain{
+		fixedProvider("override", time.Time{}, errors.New("not set")),
+		cachedStartupProvider{
+			path:  filepath.Join(t.TempDir(), startupCacheFile),
+			chain: StartupChain{countingProvider("journal", now, &calls)},
+			boot:  func() (time.Time, error) { return now, nil },
+		},
+	}
+	if _, source, err := chain.Detect(); err != nil || source != "journal" {
+		t.Fatalf("Detect() source = %q, err = %v, want \"journal\"", source, err)
+	}
+}
+
+func TestRetryStartupProvider(t *testing.T) {
+	now := time.Now()
+	calls := 0
+	flaky := NewStartupProvider("flaky", func() (time.Time, error) {
+		calls++
+		if calls < 3 {
+			return time.Time{}, errors.New("timeout")
+		}
+		return now, nil
+	})
+
+	got, err := RetryStartupProvider(flaky, 3, 0).Startup()
+	if err != nil || !got.Equal(now) {
+		t.Fatalf("Startup() = %v, %v, want %v", got, err, now)
+	}
+
+	calls = 0
+	if _, err := RetryStartupProvider(flaky, 2, 0).Startup(); err == nil {
+		t.Fatal("expected the last error once the attempts are exhausted")
+	}
+	if calls != 2 {
+		t.Fatalf("provider called %d times, want 2", calls)
+	}
+}
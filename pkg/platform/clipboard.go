@@ -0,0 +1,13 @@
+//go:build !windows && !linux && !darwin
+// +build !windows,!linux,!darwin
+
+package platform
+
+import (
+	"fmt"
+)
+
+// CopyToClipboard puts text on the system clipboard.
+func CopyToClipboard(text string) error {
+	return fmt.Errorf("CopyToClipboard function not implemented for this platform")
+}
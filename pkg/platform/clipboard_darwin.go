@@ -0,0 +1,16 @@
+//go:build darwin
+// +build darwin
+
+package platform
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// CopyToClipboard puts text on the system clipboard using pbcopy.
+func CopyToClipboard(text string) error {
+	cmd := exec.Command("pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
@@ -0,0 +1,37 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CopyToClipboard puts text on the system clipboard using the first available tool:
+// wl-copy under Wayland, xclip or xsel under X11, and clip.exe under WSL.
+func CopyToClipboard(text string) error {
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-copy"})
+	}
+	commands = append(commands,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"},
+	)
+	if IsWSL() {
+		commands = append([][]string{{"clip.exe"}}, commands...)
+	}
+
+	for _, c := range commands {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(c[0], c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New("no clipboard tool found, install wl-clipboard, xclip or xsel")
+}
@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"os/exec"
+)
+
+// CopyToClipboard puts text on the system clipboard using PowerShell's Set-Clipboard,
+// clip.exe would mangle non ASCII characters.
+func CopyToClipboard(text string) error {
+	return exec.Command("powershell", "-NoProfile", "-Command", "Set-Clipboard -Value "+powershellString(text)).Run()
+}
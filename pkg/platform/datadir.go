@@ -0,0 +1,55 @@
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// DataDirEnv is the environment variable overriding the directory in which timely keeps its data.
+const DataDirEnv = "TIMELY_DATA_DIR"
+
+// DataDir returns the directory in which timely keeps its data, creating it if needed:
+//   - the TIMELY_DATA_DIR environment variable when set
+//   - %LOCALAPPDATA%\timely on Windows
+//   - ~/Library/Application Support/timely on macOS
+//   - $XDG_DATA_HOME/timely, defaulting to ~/.local/share/timely, elsewhere
+func DataDir() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func dataDir() (string, error) {
+	if dir := os.Getenv(DataDirEnv); dir != "" {
+		return dir, nil
+	}
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return filepath.Join(dir, "timely"), nil
+		}
+		return "", errors.New("%LOCALAPPDATA% is not set")
+	case "darwin", "ios":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "Application Support", "timely"), nil
+	default:
+		if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+			return filepath.Join(dir, "timely"), nil
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".local", "share", "timely"), nil
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"syscall"
+)
+
+// Win32 libraries used by the Windows implementations
+var (
+	user32   = syscall.NewLazyDLL("user32.dll")
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+)
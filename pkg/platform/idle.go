@@ -0,0 +1,14 @@
+//go:build !windows && !linux && !darwin
+// +build !windows,!linux,!darwin
+
+package platform
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdleTime returns how long the user has been idle (no keyboard or mouse input).
+func IdleTime() (time.Duration, error) {
+	return 0, fmt.Errorf("IdleTime function not implemented for this platform")
+}
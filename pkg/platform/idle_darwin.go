@@ -0,0 +1,33 @@
+//go:build darwin
+// +build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// hidIdleTime extracts the "HIDIdleTime" = 123456789 property printed by ioreg
+var hidIdleTime = regexp.MustCompile(`"HIDIdleTime" = (\d+)`)
+
+// IdleTime returns how long the user has been idle (no keyboard or mouse input)
+// by reading the HIDIdleTime property (in nanoseconds) of the IOHIDSystem.
+func IdleTime() (time.Duration, error) {
+	output, err := exec.Command("ioreg", "-c", "IOHIDSystem", "-d", "4").Output()
+	if err != nil {
+		return 0, err
+	}
+	match := hidIdleTime.FindSubmatch(output)
+	if match == nil {
+		return 0, fmt.Errorf("HIDIdleTime not found in ioreg output")
+	}
+	ns, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ns), nil
+}
@@ -0,0 +1,50 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mutterIdleTime extracts the value of the gdbus reply "(uint64 12345,)"
+var mutterIdleTime = regexp.MustCompile(`uint64 (\d+)`)
+
+// IdleTime returns how long the user has been idle (no keyboard or mouse input).
+//
+// Linux has no single idle API shared by every display server, the following
+// sources are tried in order:
+//   - xprintidle, available on X11 sessions (and XWayland applications)
+//   - the GNOME Mutter IdleMonitor D-Bus interface, which also works on Wayland
+//
+// Both report milliseconds. An error is returned when no source is available.
+func IdleTime() (time.Duration, error) {
+	if output, err := exec.Command("xprintidle").Output(); err == nil {
+		ms, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+		if err == nil {
+			return time.Duration(ms) * time.Millisecond, nil
+		}
+	}
+
+	output, err := exec.Command("gdbus", "call", "--session",
+		"--dest", "org.gnome.Mutter.IdleMonitor",
+		"--object-path", "/org/gnome/Mutter/IdleMonitor/Core",
+		"--method", "org.gnome.Mutter.IdleMonitor.GetIdletime").Output()
+	if err != nil {
+		return 0, fmt.Errorf("no idle time source available (tried xprintidle and Mutter IdleMonitor): %w", err)
+	}
+	match := mutterIdleTime.FindStringSubmatch(string(output))
+	if match == nil {
+		return 0, fmt.Errorf("unexpected IdleMonitor reply: %s", strings.TrimSpace(string(output)))
+	}
+	ms, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
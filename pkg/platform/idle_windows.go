@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+)
+
+var (
+	procGetLastInputInfo = user32.NewProc("GetLastInputInfo")
+	procGetTickCount     = kernel32.NewProc("GetTickCount")
+)
+
+// lastInputInfo mirrors the LASTINPUTINFO structure.
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+// IdleTime returns how long the user has been idle (no keyboard or mouse input)
+// using the GetLastInputInfo Win32 API.
+func IdleTime() (time.Duration, error) {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	if ret, _, err := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info))); ret == 0 {
+		return 0, fmt.Errorf("GetLastInputInfo failed: %w", err)
+	}
+	ticks, _, _ := procGetTickCount.Call()
+	// Both values are 32 bits tick counts, the unsigned subtraction handles the wrap around
+	return time.Duration(uint32(ticks)-info.dwTime) * time.Millisecond, nil
+}
@@ -0,0 +1,13 @@
+//go:build !windows && !linux && !darwin
+// +build !windows,!linux,!darwin
+
+package platform
+
+import (
+	"fmt"
+)
+
+// Notify displays a desktop notification with the given title and body.
+func Notify(title, body string) error {
+	return fmt.Errorf("Notify function not implemented for this platform")
+}
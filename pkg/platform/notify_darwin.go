@@ -0,0 +1,24 @@
+//go:build darwin
+// +build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// Notify displays a desktop notification with the given title and body using
+// the Notification Center through osascript.
+func Notify(title, body string) error {
+	script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+	return exec.Command("osascript", "-e", script).Run()
+}
@@ -0,0 +1,15 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"os/exec"
+)
+
+// Notify displays a desktop notification with the given title and body using
+// notify-send, which forwards it to the org.freedesktop.Notifications D-Bus
+// service implemented by every major desktop environment.
+func Notify(title, body string) error {
+	return exec.Command("notify-send", "--app-name=timely", title, body).Run()
+}
@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// powershellAppID is the application user model ID of PowerShell. Toasts can
+// only be raised on behalf of a registered application, borrowing PowerShell's
+// identity avoids having to register timely.
+const powershellAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// toastScript builds a toast notification using the WinRT API and shows it.
+const toastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text[0].AppendChild($xml.CreateTextNode(%s)) > $null
+$text[1].AppendChild($xml.CreateTextNode(%s)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier(%s).Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+
+// powershellString quotes s as a single quoted PowerShell string literal.
+func powershellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Notify displays a toast notification with the given title and body.
+func Notify(title, body string) error {
+	script := fmt.Sprintf(toastScript, powershellString(title), powershellString(body), powershellString(powershellAppID))
+	return exec.Command("powershell", "-NoProfile", "-Command", script).Run()
+}
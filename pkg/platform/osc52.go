@@ -0,0 +1,11 @@
+package platform
+
+import (
+	"encoding/base64"
+)
+
+// ClipboardSequence returns the OSC 52 escape sequence asking the terminal to put
+// text on the clipboard. It also works over SSH, when the terminal supports it.
+func ClipboardSequence(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
@@ -0,0 +1,62 @@
+package platform
+
+import (
+	"time"
+)
+
+// PowerSupply is the source of power of the machine.
+type PowerSupply int
+
+const (
+	// PowerUnknown is reported when the power source cannot be determined.
+	PowerUnknown PowerSupply = iota
+	// PowerAC means the machine is plugged in.
+	PowerAC
+	// PowerBattery means the machine runs on battery.
+	PowerBattery
+)
+
+func (p PowerSupply) String() string {
+	switch p {
+	case PowerAC:
+		return "AC"
+	case PowerBattery:
+		return "battery"
+	default:
+		return "unknown"
+	}
+}
+
+// PowerChange describes a change of power source.
+type PowerChange struct {
+	// Source is the new power source.
+	Source PowerSupply
+	// At is the time at which the change was noticed.
+	At time.Time
+}
+
+// WatchPowerSource polls the power source every interval and reports changes on
+// the returned channel. The initial power source is not reported, and polling
+// stops if the power source cannot be determined on this platform.
+func WatchPowerSource(interval time.Duration) <-chan PowerChange {
+	changes := make(chan PowerChange)
+	go func() {
+		defer close(changes)
+		last, err := PowerSource()
+		if err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			current, err := PowerSource()
+			if err != nil || current == last {
+				continue
+			}
+			last = current
+			changes <- PowerChange{Source: current, At: now.Round(0)}
+		}
+	}()
+	return changes
+}
@@ -0,0 +1,26 @@
+//go:build darwin
+// +build darwin
+
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PowerSource returns whether the machine currently runs on AC or on battery by
+// parsing the first line of "pmset -g batt" ("Now drawing from 'AC Power'").
+func PowerSource() (PowerSupply, error) {
+	output, err := exec.Command("pmset", "-g", "batt").Output()
+	if err != nil {
+		return PowerUnknown, err
+	}
+	switch {
+	case strings.Contains(string(output), "'AC Power'"):
+		return PowerAC, nil
+	case strings.Contains(string(output), "'Battery Power'"):
+		return PowerBattery, nil
+	}
+	return PowerUnknown, fmt.Errorf("unexpected pmset output %q", strings.TrimSpace(string(output)))
+}
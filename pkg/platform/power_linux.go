@@ -0,0 +1,49 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// powerSupplyDir is where the kernel exposes the power supplies.
+const powerSupplyDir = "/sys/class/power_supply"
+
+// PowerSource returns whether the machine currently runs on AC or on battery,
+// based on the power supplies exposed by the kernel in sysfs: the machine runs
+// on AC when a mains adapter is online or when it has no battery at all.
+func PowerSource() (PowerSupply, error) {
+	supplies, err := os.ReadDir(powerSupplyDir)
+	if err != nil {
+		return PowerUnknown, err
+	}
+
+	battery := false
+	for _, s := range supplies {
+		kind := readSysfs(filepath.Join(powerSupplyDir, s.Name(), "type"))
+		switch kind {
+		case "Mains", "USB":
+			if readSysfs(filepath.Join(powerSupplyDir, s.Name(), "online")) == "1" {
+				return PowerAC, nil
+			}
+		case "Battery":
+			battery = true
+		}
+	}
+	if battery {
+		return PowerBattery, nil
+	}
+	return PowerAC, nil
+}
+
+// readSysfs returns the trimmed content of a sysfs attribute, or an empty string.
+func readSysfs(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
@@ -0,0 +1,13 @@
+//go:build !windows && !linux && !darwin
+// +build !windows,!linux,!darwin
+
+package platform
+
+import (
+	"fmt"
+)
+
+// PowerSource returns whether the machine currently runs on AC or on battery.
+func PowerSource() (PowerSupply, error) {
+	return PowerUnknown, fmt.Errorf("PowerSource function not implemented for this platform")
+}
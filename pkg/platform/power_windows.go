@@ -0,0 +1,38 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+var procGetSystemPowerStatus = kernel32.NewProc("GetSystemPowerStatus")
+
+// systemPowerStatus mirrors the SYSTEM_POWER_STATUS structure.
+type systemPowerStatus struct {
+	ACLineStatus        byte
+	BatteryFlag         byte
+	BatteryLifePercent  byte
+	SystemStatusFlag    byte
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+// PowerSource returns whether the machine currently runs on AC or on battery
+// using the GetSystemPowerStatus Win32 API.
+func PowerSource() (PowerSupply, error) {
+	var status systemPowerStatus
+	if ret, _, err := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status))); ret == 0 {
+		return PowerUnknown, fmt.Errorf("GetSystemPowerStatus failed: %w", err)
+	}
+	switch status.ACLineStatus {
+	case 0:
+		return PowerBattery, nil
+	case 1:
+		return PowerAC, nil
+	default:
+		return PowerUnknown, nil
+	}
+}
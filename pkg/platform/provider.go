@@ -0,0 +1,139 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// StartupEnv is the environment variable overriding the detected startup time.
+const StartupEnv = "TIMELY_STARTUP"
+
+// StartupSourceOverride is the name of the providers returned by EnvStartupProvider
+// and FixedStartupProvider, reported when the startup time was not detected but given.
+const StartupSourceOverride = "override"
+
+// StartupProvider is a source able to tell when the machine was started today.
+type StartupProvider interface {
+	// Name identifies the source, it is reported to the user.
+	Name() string
+	// Startup returns the startup time.
+	Startup() (time.Time, error)
+}
+
+// startupFunc adapts a function to the StartupProvider interface.
+type startupFunc struct {
+	name string
+	fn   func() (time.Time, error)
+}
+
+func (s startupFunc) Name() string                { return s.name }
+func (s startupFunc) Startup() (time.Time, error) { return s.fn() }
+
+// NewStartupProvider creates a StartupProvider named name backed by fn.
+func NewStartupProvider(name string, fn func() (time.Time, error)) StartupProvider {
+	return startupFunc{name: name, fn: fn}
+}
+
+// EnvStartupProvider returns a provider reading the startup time from the
+// TIMELY_STARTUP environment variable, in any format accepted by
+// timeutils.ParseTime. It fails when the variable is not set.
+func EnvStartupProvider() StartupProvider {
+	return NewStartupProvider(StartupSourceOverride, func() (time.Time, error) {
+		value := os.Getenv(StartupEnv)
+		if value == "" {
+			return time.Time{}, fmt.Errorf("%s is not set", StartupEnv)
+		}
+		return timeutils.ParseTime(value)
+	})
+}
+
+// RetryStartupProvider returns a provider retrying p up to attempts times, waiting delay
+// between attempts, for sources which fail transiently (e.g. a slow PowerShell start).
+func RetryStartupProvider(p StartupProvider, attempts int, delay time.Duration) StartupProvider {
+	return NewStartupProvider(p.Name(), func() (time.Time, error) {
+		var err error
+		for i := 0; i < attempts; i++ {
+			if i > 0 {
+				time.Sleep(delay)
+			}
+			var t time.Time
+			if t, err = p.Startup(); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, err
+	})
+}
+
+// FixedStartupProvider returns a provider always reporting t, typically used to
+// honor an explicit command line override.
+func FixedStartupProvider(t time.Time) StartupProvider {
+	return NewStartupProvider(StartupSourceOverride, func() (time.Time, error) {
+		return t, nil
+	})
+}
+
+// StartupChain is an ordered list of providers. Providers are tried in order and
+// the first one to succeed wins.
+type StartupChain []StartupProvider
+
+// detector is implemented by providers wrapping other providers, which report
+// the name of the one which actually detected the startup time.
+type detector interface {
+	Detect() (time.Time, string, error)
+}
+
+// DefaultStartupChain returns the chain used by Startup: the environment
+// override followed by the platform specific providers, most reliable first.
+// The result of the platform providers is cached in the data directory when available.
+func DefaultStartupChain() StartupChain {
+	providers := StartupChain(platformStartupProviders())
+	if path, err := DefaultStartupCachePath(); err == nil && len(providers) > 0 {
+		return StartupChain{EnvStartupProvider(), CachedStartupProvider(path, providers)}
+	}
+	return append(StartupChain{EnvStartupProvider()}, providers...)
+}
+
+// Detect returns the startup time reported by the first successful provider along
+// with the name of that provider, or of the provider it wrapped. A provider reporting a time which is not today
+// (e.g. the machine booted yesterday and was never turned off) is considered to
+// have failed. When every provider fails, the returned error lists all the failures.
+func (c StartupChain) Detect() (time.Time, string, error) {
+	var errs []error
+	now := time.Now()
+	for _, p := range c {
+		var t time.Time
+		var err error
+		name := p.Name()
+		if d, ok := p.(detector); ok {
+			t, name, err = d.Detect()
+		} else {
+			t, err = p.Startup()
+		}
+		if err != nil {
+			name = p.Name()
+		}
+		if err == nil && !timeutils.SameDay(t, now) {
+			err = fmt.Errorf("%s is not today", t.Format(time.DateTime))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		return t, name, nil
+	}
+	if len(errs) == 0 {
+		return time.Time{}, "", errors.New("no startup provider available for this platform")
+	}
+	return time.Time{}, "", errors.Join(errs...)
+}
+
+// Startup returns the system startup time using the DefaultStartupChain.
+func Startup() (time.Time, error) {
+	t, _, err := DefaultStartupChain().Detect()
+	return t, err
+}
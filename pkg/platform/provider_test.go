@@ -0,0 +1,78 @@
+package platform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedProvider(name string, t time.Time, err error) StartupProvider {
+	return NewStartupProvider(name, func() (time.Time, error) { return t, err })
+}
+
+func TestStartupChain_FirstSuccessWins(t *testing.T) {
+	now := time.Now()
+	chain := StartupChain{
+		fixedProvider("broken", time.Time{}, errors.New("boom")),
+		fixedProvider("first", now, nil),
+		fixedProvider("second", now, nil),
+	}
+
+	got, source, err := chain.Detect()
+	if err != nil {
+		t.Fatalf("Detect() returned error: %v", err)
+	}
+	if source != "first" || !got.Equal(now) {
+		t.Fatalf("Detect() = %v from %q, want result of \"first\"", got, source)
+	}
+}
+
+func TestStartupChain_SkipsPreviousDays(t *testing.T) {
+	now := time.Now()
+	chain := StartupChain{
+		fixedProvider("yesterday", now.AddDate(0, 0, -1), nil),
+		fixedProvider("today", now, nil),
+	}
+
+	if _, source, err := chain.Detect(); err != nil || source != "today" {
+		t.Fatalf("Detect() source = %q, err = %v, want \"today\"", source, err)
+	}
+}
+
+func TestStartupChain_AllFail(t *testing.T) {
+	chain := StartupChain{
+		fixedProvider("a", time.Time{}, errors.New("first failure")),
+		fixedProvider("b", time.Time{}, errors.New("second failure")),
+	}
+
+	_, _, err := chain.Detect()
+	if err == nil {
+		t.Fatal("expected an error when every provider fails")
+	}
+	for _, want := range []string{"a: first failure", "b: second failure"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	if _, _, err := (StartupChain{}).Detect(); err == nil {
+		t.Fatal("expected an error for an empty chain")
+	}
+}
+
+func TestEnvStartupProvider(t *testing.T) {
+	t.Setenv(StartupEnv, "")
+	if _, err := EnvStartupProvider().Startup(); err == nil {
+		t.Fatal("expected an error when the variable is not set")
+	}
+
+	t.Setenv(StartupEnv, "7:45")
+	got, err := EnvStartupProvider().Startup()
+	if err != nil {
+		t.Fatalf("Startup() returned error: %v", err)
+	}
+	if got.Format("15:04") != "07:45" {
+		t.Fatalf("Startup() = %s, want 07:45", got.Format("15:04"))
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package platform
+
+import (
+	"fmt"
+	"time"
+)
+
+// ResumeTimes returns the times at which the system resumed from sleep since the given time.
+func ResumeTimes(since time.Time) ([]time.Time, error) {
+	return nil, fmt.Errorf("ResumeTimes function not implemented for this platform")
+}
@@ -0,0 +1,64 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resumeMessages are the messages logged by systemd-sleep when the system wakes
+// up, depending on the systemd version.
+var resumeMessages = []string{
+	"System returned from sleep",
+	"System resumed",
+}
+
+// ResumeTimes returns the times at which the system resumed from sleep since the
+// given time, oldest first. The events are mined from the messages logged by
+// systemd-sleep in the systemd journal.
+func ResumeTimes(since time.Time) ([]time.Time, error) {
+	output, err := exec.Command("journalctl",
+		"--since", since.Format(time.DateTime),
+		"--identifier=systemd-sleep",
+		"--output=short-unix", "--quiet", "--no-pager").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseResumeTimes(bytes.NewReader(output))
+}
+
+// parseResumeTimes extracts the resume events from journal lines formatted as
+// "1735804801.123456 hostname systemd-sleep[42]: System returned from sleep...".
+func parseResumeTimes(r io.Reader) ([]time.Time, error) {
+	var times []time.Time
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !isResumeMessage(line) {
+			continue
+		}
+		stamp, _, _ := strings.Cut(line, " ")
+		seconds, err := strconv.ParseFloat(stamp, 64)
+		if err != nil {
+			continue
+		}
+		times = append(times, time.Unix(0, int64(seconds*float64(time.Second))))
+	}
+	return times, scanner.Err()
+}
+
+func isResumeMessage(line string) bool {
+	for _, m := range resumeMessages {
+		if strings.Contains(line, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResumeTimes(t *testing.T) {
+	journal := strings.Join([]string{
+		"1735804801.000000 laptop systemd-sleep[812]: Entering sleep state 'suspend'...",
+		"1735804861.500000 laptop systemd-sleep[812]: System returned from sleep operation 'suspend'.",
+		"1735808401.000000 laptop systemd-sleep[900]: Performing sleep operation 'suspend'...",
+		"1735808461.000000 laptop systemd-sleep[900]: System resumed.",
+		"garbage systemd-sleep[900]: System resumed.",
+	}, "\n")
+
+	got, err := parseResumeTimes(strings.NewReader(journal))
+	if err != nil {
+		t.Fatalf("parseResumeTimes returned error: %v", err)
+	}
+	want := []time.Time{time.Unix(1735804861, 500000000), time.Unix(1735808461, 0)}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("got[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
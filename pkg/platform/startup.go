@@ -1,13 +1,10 @@
-//go:build !windows && !linux
-// +build !windows,!linux
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !windows,!linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
 
 package platform
 
-import (
-	"fmt"
-	"time"
-)
-
-func Startup() (time.Time, error) {
-	return time.Time{}, fmt.Errorf("Startup function not implemented for this platform")
+// platformStartupProviders returns the startup providers available on this
+// platform. Only the environment override is supported here.
+func platformStartupProviders() []StartupProvider {
+	return nil
 }
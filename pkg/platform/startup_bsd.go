@@ -0,0 +1,26 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package platform
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// platformStartupProviders returns the BSD startup providers: the kernel boot time.
+func platformStartupProviders() []StartupProvider {
+	return []StartupProvider{
+		NewStartupProvider("kern.boottime", bootTime),
+	}
+}
+
+// bootTime returns the boot time recorded by the kernel in the kern.boottime sysctl.
+func bootTime() (time.Time, error) {
+	tv, err := unix.SysctlTimeval("kern.boottime")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(tv.Unix()), nil
+}
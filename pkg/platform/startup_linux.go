@@ -4,51 +4,123 @@
 package platform
 
 import (
+	"bufio"
+	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// Startup returns the system startup time on Linux systems by using the who -b command
-// Startup returns the system boot time constructed from the output of the external
-// command "who -b".
+// platformStartupProviders returns the Linux startup providers: the kernel boot
+// time, the first journal entry of the current boot and finally "who -b".
+//
+// None of them is used under WSL: they describe the lightweight VM hosting the
+// distribution, which starts whenever a WSL shell is first opened and keeps
+// running across Windows sleeps, rather than the user's workday.
+func platformStartupProviders() []StartupProvider {
+	if IsWSL() {
+		return nil
+	}
+	return []StartupProvider{
+		NewStartupProvider("btime", bootTimeStartup),
+		NewStartupProvider("journal", journalStartup),
+		NewStartupProvider("who -b", whoStartup),
+	}
+}
+
+// bootTimeStartup returns the boot time recorded by the kernel (the "btime"
+// line of /proc/stat, in seconds since the epoch).
+func bootTimeStartup() (time.Time, error) {
+	f, err := os.Open("/proc/stat")
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid btime %q: %w", value, err)
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, fmt.Errorf("btime not found in /proc/stat")
+}
+
+// journalStartup returns the timestamp of the first systemd journal entry of the
+// current boot. Only the first line is read, journalctl is stopped right after.
+func journalStartup() (time.Time, error) {
+	cmd := exec.Command("journalctl", "--boot", "--output=short-unix", "--quiet", "--no-pager")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		return time.Time{}, err
+	}
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		return time.Time{}, fmt.Errorf("no journal entry for the current boot: %w", err)
+	}
+	// Lines look like "1735804801.123456 hostname kernel: message"
+	stamp, _, _ := strings.Cut(line, " ")
+	seconds, err := strconv.ParseFloat(stamp, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid journal timestamp %q: %w", stamp, err)
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
+
+// whoStartup returns the system boot time constructed from the output of the
+// external command "who -b".
 //
 // Behavior:
 //   - Executes the command "who -b" and reads its stdout. If the command fails the
 //     returned error is non-nil and the zero time is returned.
-//   - The implementation expects the command output to contain a time component at a
-//     fixed offset and slices the output (skipping the date portion) to extract an
-//     "HH:MM" string, then parses hours and minutes with strconv.Atoi.
-//   - The returned time.Time is built using the current year, month and day (time.Now()),
-//     the parsed hour and minute, zero seconds and nanoseconds, and the current local
-//     location (now.Location()).
+//   - The last two fields of the output are expected to be the boot date and time
+//     ("2025-01-02 08:00") and are parsed in the local location.
+//   - Some implementations print the date in another format ("Jan  2 08:00"), in this
+//     case only the trailing "HH:MM" field is used and today's date is assumed.
 //
 // Important caveats and limitations:
 //   - This function is platform- and output-format dependent (relies on "who -b" and a
 //     specific output layout) and is not robust to variations in that output.
-//   - The code ignores parsing errors for hours/minutes (strconv.Atoi errors are discarded);
-//     if parsing fails the hour and/or minute default to zero and the function will return
-//     a time on the current date at 00:00 with a nil error.
-//   - The date portion of the boot time is intentionally skipped: the function uses today's
-//     date rather than the actual boot date, which can produce incorrect results for boots
-//     that occurred on a previous day (e.g., across midnight) or when the system clock has
-//     changed.
 //   - This approach may not work in restricted environments (missing "who" binary, PATH
-//     differences, containers) and should be used with caution. Consider using a more
-//     robust method (e.g., parsing /proc/uptime or using system APIs) for production code.
-func Startup() (time.Time, error) {
+//     differences, containers), which is why it comes last in the provider chain.
+func whoStartup() (time.Time, error) {
 	cmd := exec.Command("who", "-b")
 	output, err := cmd.Output()
 	if err != nil {
 		return time.Time{}, err
 	}
-	// Dodgy and dangerous - we skip the date part
-	startupTimeStr := strings.TrimSpace(string(output))[24:]
-	hours, _ := strconv.Atoi(startupTimeStr[0:2])
-	minutes, _ := strconv.Atoi(startupTimeStr[3:5])
-	now := time.Now()
 
-	// Clean up the output by removing newlines and extra spaces
-	return time.Date(now.Year(), now.Month(), now.Day(), hours, minutes, 0, 0, now.Location()), nil
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 {
+		return time.Time{}, fmt.Errorf("unexpected who -b output %q", strings.TrimSpace(string(output)))
+	}
+	date, clock := fields[len(fields)-2], fields[len(fields)-1]
+	if t, err := time.ParseInLocation("2006-01-02 15:04", date+" "+clock, time.Local); err == nil {
+		return t, nil
+	}
+
+	// Dodgy - the date part is not in ISO format, assume the boot happened today
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected who -b output %q", strings.TrimSpace(string(output)))
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
 }
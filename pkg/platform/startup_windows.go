@@ -4,41 +4,73 @@
 package platform
 
 import (
+	"fmt"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 )
 
-// Startup retrieves the system startup time on Windows by querying the System EventLog.
-// It executes a PowerShell command to get the last event log entry's timestamp from the current day.
-// The function returns a time.Time object representing the startup time and an error.
+// platformStartupProviders returns the Windows startup providers: the boot time
+// derived from the system uptime, then the System event log.
+func platformStartupProviders() []StartupProvider {
+	return []StartupProvider{
+		NewStartupProvider("uptime", uptimeStartup),
+		// PowerShell sometimes times out right after logon, while the system is busy
+		RetryStartupProvider(NewStartupProvider("event log", eventLogStartup), 3, 2*time.Second),
+	}
+}
+
+// uptimeStartup returns the boot time computed from the system uptime.
+func uptimeStartup() (time.Time, error) {
+	uptime, err := Uptime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-uptime), nil
+}
+
+// eventLogQuery lists, oldest first, the events of the day which mark the
+// machine becoming available:
+//   - EventLog 6005: the event log service started, logged on every boot
+//   - Kernel-General 12: the operating system started
+//   - Power-Troubleshooter 1: the system resumed from sleep
 //
-// The returned time will have the current date but with hours and minutes from the startup event.
-// Seconds and nanoseconds are set to 0.
+// EventLog 6013 (system uptime) is deliberately left out: besides boot it is
+// also logged every day at noon, which would be mistaken for a start.
+const eventLogQuery = `$day = (Get-Date).Date
+Get-WinEvent -ErrorAction SilentlyContinue -FilterHashtable @(
+	@{LogName='System'; ProviderName='EventLog'; Id=6005; StartTime=$day},
+	@{LogName='System'; ProviderName='Microsoft-Windows-Kernel-General'; Id=12; StartTime=$day},
+	@{LogName='System'; ProviderName='Microsoft-Windows-Power-Troubleshooter'; Id=1; StartTime=$day}
+) | Sort-Object TimeCreated | Select-Object -First 1 | ForEach-Object { $_.TimeCreated.ToString('yyyy-MM-ddTHH:mm:ss') }`
+
+// eventLogStartup retrieves the system startup time on Windows by querying the System
+// event log for the first boot or resume event of the current day (see eventLogQuery).
+// Filtering on well known event IDs, rather than taking whatever event came first,
+// avoids returning the time of an unrelated event such as a service or driver message.
 //
 // Note: This implementation has limitations as it:
 // - Only works on Windows systems
 // - Requires PowerShell to be available
-// - Assumes the last event log entry corresponds to startup
-// - Ignores potential errors from time parsing
 //
 // Returns:
-//   - time.Time: The system startup time with current date
-//   - error: Any error encountered during execution of the PowerShell command
-func Startup() (time.Time, error) {
-	cmd := exec.Command("powershell", "-Command", " (Get-EventLog -LogName System -After (Get-Date -Hour 0 -Minute 0 -Second 0 -Millisecond 0) | Select-Object -Last 1).TimeGenerated.ToString(\"HH:mm\")")
-	output, err := cmd.CombinedOutput()
-	outputStr := ""
-	if err == nil {
-		outputStr = strings.Trim(string(output), "\r\n")
+//   - time.Time: The time of the first boot or resume event of the day
+//   - error: Any error encountered during execution of the PowerShell command, or when
+//     no matching event was logged today
+func eventLogStartup() (time.Time, error) {
+	cmd := exec.Command("powershell", "-NoProfile", "-Command", eventLogQuery)
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+	outputStr := strings.TrimSpace(string(output))
+	if outputStr == "" {
+		return time.Time{}, fmt.Errorf("no boot or resume event logged today")
 	}
 
-	// Dodgy and dangerous - we skip the date part
-	hours, _ := strconv.Atoi(outputStr[0:2])
-	minutes, _ := strconv.Atoi(outputStr[3:5])
-	now := time.Now()
-
-	// Clean up the output by removing newlines and extra spaces
-	return time.Date(now.Year(), now.Month(), now.Day(), hours, minutes, 0, 0, now.Location()), nil
+	t, err := time.ParseInLocation("2006-01-02T15:04:05", outputStr, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected event log output %q: %w", outputStr, err)
+	}
+	return t, nil
 }
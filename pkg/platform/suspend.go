@@ -0,0 +1,50 @@
+package platform
+
+import (
+	"time"
+)
+
+// minSuspendGap is the smallest gap reported as a suspension. It keeps small
+// wall clock adjustments (e.g. NTP corrections) from being mistaken for a sleep.
+const minSuspendGap = time.Minute
+
+// SuspendEvent describes a period during which the system was suspended.
+type SuspendEvent struct {
+	// Suspended is the (approximate) time at which the system went to sleep.
+	Suspended time.Time
+	// Resumed is the time at which the system woke up.
+	Resumed time.Time
+}
+
+// WatchSuspend detects system suspend/resume cycles and reports them on the
+// returned channel once the system has resumed.
+//
+// Rather than subscribing to platform specific power notifications (logind
+// PrepareForSleep signals, Windows power broadcast messages...), it relies on a
+// property shared by all supported platforms: a process does not run while the
+// system sleeps. A ticker fires every interval and compares the elapsed wall
+// clock time with the expected interval; when the difference exceeds one minute
+// the system is considered to have been suspended in between.
+//
+// Note: the suspension start time is only accurate to the given interval, and
+// a process stopped for a long time (e.g. with SIGSTOP) or a large forward
+// jump of the system clock is reported as a suspension as well.
+func WatchSuspend(interval time.Duration) <-chan SuspendEvent {
+	events := make(chan SuspendEvent)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// Round(0) strips the monotonic clock reading: we want the wall clock to
+		// include the time spent sleeping, which the monotonic clock may not.
+		last := time.Now().Round(0)
+		for range ticker.C {
+			now := time.Now().Round(0)
+			if now.Sub(last)-interval > minSuspendGap {
+				events <- SuspendEvent{Suspended: last, Resumed: now}
+			}
+			last = now
+		}
+	}()
+	return events
+}
@@ -0,0 +1,18 @@
+package platform
+
+// TaskbarState is the state of the progress indicator displayed on the
+// terminal's taskbar button. Values match the ConEmu OSC 9;4 specification.
+type TaskbarState int
+
+const (
+	// TaskbarNone removes the progress indicator.
+	TaskbarNone TaskbarState = iota
+	// TaskbarNormal displays a regular progress indicator.
+	TaskbarNormal
+	// TaskbarError displays the progress indicator in the error (red) color.
+	TaskbarError
+	// TaskbarIndeterminate displays an indeterminate progress indicator.
+	TaskbarIndeterminate
+	// TaskbarPaused displays the progress indicator in the paused (yellow) color.
+	TaskbarPaused
+)
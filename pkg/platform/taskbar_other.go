@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package platform
+
+// TaskbarProgress returns the escape sequence reflecting the given state and
+// completion percentage on the taskbar button. Taskbar progress is only
+// supported on Windows, an empty string is returned on other platforms.
+func TaskbarProgress(state TaskbarState, percent float64) string {
+	return ""
+}
@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"fmt"
+	"os"
+)
+
+// TaskbarProgress returns the escape sequence reflecting the given state and
+// completion percentage (between 0 and 1) on the taskbar button.
+//
+// Console applications do not own their window, the ITaskbarList3 interface
+// can therefore not be used directly. Windows Terminal however maps the
+// ConEmu "OSC 9;4" progress sequence to ITaskbarList3 on our behalf. An empty
+// string is returned when not running inside Windows Terminal (detected using
+// the WT_SESSION environment variable) as other consoles would print garbage.
+func TaskbarProgress(state TaskbarState, percent float64) string {
+	if os.Getenv("WT_SESSION") == "" {
+		return ""
+	}
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 1 {
+		percent = 1
+	}
+	return fmt.Sprintf("\x1b]9;4;%d;%d\x1b\\", state, int(percent*100))
+}
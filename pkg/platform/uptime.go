@@ -0,0 +1,14 @@
+//go:build !windows && !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !windows,!linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package platform
+
+import (
+	"fmt"
+	"time"
+)
+
+// Uptime returns for how long the system has been running.
+func Uptime() (time.Duration, error) {
+	return 0, fmt.Errorf("Uptime function not implemented for this platform")
+}
@@ -0,0 +1,18 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package platform
+
+import (
+	"time"
+)
+
+// Uptime returns for how long the system has been running, computed from the
+// kern.boottime sysctl. Time spent suspended is included.
+func Uptime() (time.Duration, error) {
+	boot, err := bootTime()
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(boot), nil
+}
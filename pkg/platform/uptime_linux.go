@@ -0,0 +1,19 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"syscall"
+	"time"
+)
+
+// Uptime returns for how long the system has been running using the sysinfo
+// system call. Time spent suspended is included.
+func Uptime() (time.Duration, error) {
+	var info syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&info); err != nil {
+		return 0, err
+	}
+	return time.Duration(info.Uptime) * time.Second, nil
+}
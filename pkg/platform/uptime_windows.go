@@ -0,0 +1,26 @@
+//go:build windows
+// +build windows
+
+package platform
+
+import (
+	"time"
+	"unsafe"
+)
+
+var procGetTickCount64 = kernel32.NewProc("GetTickCount64")
+
+// Uptime returns for how long the system has been running using the
+// GetTickCount64 Win32 API. Time spent suspended is included.
+func Uptime() (time.Duration, error) {
+	if err := procGetTickCount64.Find(); err != nil {
+		return 0, err
+	}
+	lo, hi, _ := procGetTickCount64.Call()
+	ticks := uint64(lo)
+	if unsafe.Sizeof(lo) == 4 {
+		// On 32 bits platforms the upper half of the result is returned in EDX
+		ticks |= uint64(hi) << 32
+	}
+	return time.Duration(ticks) * time.Millisecond, nil
+}
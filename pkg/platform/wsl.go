@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package platform
+
+// IsWSL reports whether the program runs inside the Windows Subsystem for Linux.
+func IsWSL() bool {
+	return false
+}
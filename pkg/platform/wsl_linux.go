@@ -0,0 +1,23 @@
+//go:build linux
+// +build linux
+
+package platform
+
+import (
+	"os"
+	"strings"
+)
+
+// IsWSL reports whether the program runs inside the Windows Subsystem for Linux.
+// WSL sets WSL_DISTRO_NAME in every session and its kernels are built by
+// Microsoft, which shows in the kernel release string.
+func IsWSL() bool {
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(release)), "microsoft")
+}
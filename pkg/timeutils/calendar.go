@@ -0,0 +1,70 @@
+package timeutils
+
+import (
+	"fmt"
+	"time"
+)
+
+// Week is an ISO 8601 week. The ISO year differs from the calendar year around the
+// new year: 31 December 2024 falls in week 1 of 2025, 1 January 2021 in week 53 of 2020.
+type Week struct {
+	Year   int
+	Number int
+}
+
+// WeekOf returns the ISO week of t.
+func WeekOf(t time.Time) Week {
+	year, week := t.ISOWeek()
+	return Week{Year: year, Number: week}
+}
+
+// WeeksInYear returns the number of ISO weeks of year, either 52 or 53.
+func WeeksInYear(year int) int {
+	// 28 December always falls in the last week of its ISO year
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// Start returns midnight of the Monday of the week, in loc.
+func (w Week) Start(loc *time.Location) time.Time {
+	// 4 January always falls in the first week of its ISO year
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, loc)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+	return jan4.AddDate(0, 0, (w.Number-1)*7-offset)
+}
+
+// End returns midnight of the Monday following the week, in loc.
+func (w Week) End(loc *time.Location) time.Time {
+	return w.Start(loc).AddDate(0, 0, 7)
+}
+
+// Next returns the following week, rolling over to week 1 of the next ISO year.
+func (w Week) Next() Week {
+	if w.Number >= WeeksInYear(w.Year) {
+		return Week{Year: w.Year + 1, Number: 1}
+	}
+	return Week{Year: w.Year, Number: w.Number + 1}
+}
+
+// String formats the week as in ISO 8601, e.g. "2026-W53".
+func (w Week) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Number)
+}
+
+// SplitByYear splits the span at each new year, in the location of its start, so that
+// each part belongs to a single calendar year (e.g. for annual reports or balances).
+// An empty or reversed span returns no part.
+func SplitByYear(s Span) []Span {
+	var parts []Span
+	start := s.Start
+	for start.Before(s.End) {
+		newYear := time.Date(start.Year()+1, time.January, 1, 0, 0, 0, 0, start.Location())
+		end := s.End
+		if newYear.Before(end) {
+			end = newYear
+		}
+		parts = append(parts, Span{Start: start, End: end})
+		start = end
+	}
+	return parts
+}
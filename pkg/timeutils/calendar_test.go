@@ -0,0 +1,110 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestWeekOf_NewYear(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{day(2020, time.December, 31), "2020-W53"},
+		{day(2021, time.January, 1), "2020-W53"},
+		{day(2021, time.January, 3), "2020-W53"},
+		{day(2021, time.January, 4), "2021-W01"},
+		{day(2024, time.December, 30), "2025-W01"},
+		{day(2024, time.December, 31), "2025-W01"},
+		{day(2026, time.December, 31), "2026-W53"},
+		{day(2027, time.January, 3), "2026-W53"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date.Format(time.DateOnly), func(t *testing.T) {
+			if got := WeekOf(tt.date).String(); got != tt.want {
+				t.Errorf("WeekOf(%s) = %s, want %s", tt.date.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeksInYear(t *testing.T) {
+	for year, want := range map[int]int{2015: 53, 2019: 52, 2020: 53, 2024: 52, 2025: 52, 2026: 53} {
+		if got := WeeksInYear(year); got != want {
+			t.Errorf("WeeksInYear(%d) = %d, want %d", year, got, want)
+		}
+	}
+}
+
+func TestWeek_StartEnd(t *testing.T) {
+	tests := []struct {
+		week  Week
+		start time.Time
+	}{
+		{Week{2020, 53}, day(2020, time.December, 28)},
+		{Week{2021, 1}, day(2021, time.January, 4)},
+		{Week{2025, 1}, day(2024, time.December, 30)},
+		{Week{2026, 53}, day(2026, time.December, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.week.String(), func(t *testing.T) {
+			if got := tt.week.Start(time.UTC); !got.Equal(tt.start) {
+				t.Errorf("Start() = %s, want %s", got.Format(time.DateOnly), tt.start.Format(time.DateOnly))
+			}
+			if got := tt.week.End(time.UTC); !got.Equal(tt.start.AddDate(0, 0, 7)) {
+				t.Errorf("End() = %s, want a week after the start", got.Format(time.DateOnly))
+			}
+			if got := WeekOf(tt.week.Start(time.UTC)); got != tt.week {
+				t.Errorf("WeekOf(Start()) = %s, want %s", got, tt.week)
+			}
+		})
+	}
+}
+
+func TestWeek_Next(t *testing.T) {
+	tests := []struct {
+		week Week
+		next Week
+	}{
+		{Week{2020, 52}, Week{2020, 53}},
+		{Week{2020, 53}, Week{2021, 1}},
+		{Week{2024, 52}, Week{2025, 1}},
+		{Week{2025, 10}, Week{2025, 11}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.week.String(), func(t *testing.T) {
+			if got := tt.week.Next(); got != tt.next {
+				t.Errorf("Next() = %s, want %s", got, tt.next)
+			}
+		})
+	}
+}
+
+func TestSplitByYear(t *testing.T) {
+	span := Span{Start: time.Date(2025, time.December, 31, 22, 0, 0, 0, time.UTC), End: time.Date(2026, time.January, 1, 2, 0, 0, 0, time.UTC)}
+	parts := SplitByYear(span)
+	if len(parts) != 2 {
+		t.Fatalf("SplitByYear() returned %d parts, want 2", len(parts))
+	}
+	if parts[0].Duration() != 2*time.Hour || parts[1].Duration() != 2*time.Hour {
+		t.Errorf("parts last %v and %v, want 2h each", parts[0].Duration(), parts[1].Duration())
+	}
+	if parts[1].Start.Year() != 2026 {
+		t.Errorf("second part starts in %d, want 2026", parts[1].Start.Year())
+	}
+
+	long := SplitByYear(Span{Start: day(2024, time.June, 1), End: day(2026, time.June, 1)})
+	if len(long) != 3 {
+		t.Errorf("SplitByYear() over two years returned %d parts, want 3", len(long))
+	}
+	if got := SplitByYear(Span{Start: day(2025, time.June, 1), End: day(2025, time.June, 1)}); len(got) != 0 {
+		t.Errorf("SplitByYear() of an empty span returned %d parts, want 0", len(got))
+	}
+}
@@ -20,12 +20,35 @@ func FormatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", h, m)
 }
 
+// FormatDurationSeconds formats a time.Duration into a string in "HH:MM:SS" format,
+// extending FormatDuration for measurements needing a finer precision.
+func FormatDurationSeconds(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDurationSeconds(-d)
+	}
+	return fmt.Sprintf("%s:%02d", FormatDuration(d), int((d%time.Minute)/time.Second))
+}
+
 // FormatTime formats a time.Duration into a string in "HH:MM" format.
 // It handles negative durations by prefixing the result with a minus sign.
 func FormatTime(d time.Time) string {
 	return d.Format("15:04")
 }
 
+// SameDay reports whether both times fall on the same calendar day.
+// Each time is considered in its own location.
+func SameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// StartOfDay returns midnight of the day of t, in t's location.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
 // Durations represents an ordered collection of time.Time values.
 // The collection maintains chronological order (ascending) when elements
 // are added or removed.
@@ -76,6 +99,55 @@ func (duration Durations) StringSlice() []string {
 	return strs
 }
 
+// Span is a time interval between two instants.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the span.
+func (s Span) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Spans returns the closed worked spans of the collection: times[0]->times[1],
+// times[2]->times[3], etc. A trailing unpaired time (open span) is ignored.
+func (durations Durations) Spans() []Span {
+	spans := make([]Span, 0, len(durations)/2)
+	for i := 0; i+1 < len(durations); i += 2 {
+		spans = append(spans, Span{Start: durations[i], End: durations[i+1]})
+	}
+	return spans
+}
+
+// Breaks returns the gaps between consecutive pairs of the collection:
+// times[1]->times[2], times[3]->times[4], etc.
+func (durations Durations) Breaks() []Span {
+	breaks := make([]Span, 0, len(durations)/2)
+	for i := 1; i+1 < len(durations); i += 2 {
+		breaks = append(breaks, Span{Start: durations[i], End: durations[i+1]})
+	}
+	return breaks
+}
+
+// BreakDuration returns the time spent on breaks between the pairs of the collection.
+// When the last span is closed, the ongoing break until now is included, a zero now
+// leaves it out.
+func (durations Durations) BreakDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, b := range durations.Breaks() {
+		if d := b.Duration(); d > 0 {
+			total += d
+		}
+	}
+	if len(durations) > 0 && len(durations)%2 == 0 && !now.IsZero() {
+		if d := now.Sub(durations.Last()); d > 0 {
+			total += d
+		}
+	}
+	return total
+}
+
 // SumPairedDurations calculates the total duration between pairs of times in the Durations collection.
 // Times are already maintained in ascending order by the Durations type, and durations
 // are calculated between consecutive pairs (times[0]->times[1], times[2]->times[3], etc.).
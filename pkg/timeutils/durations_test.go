@@ -199,3 +199,126 @@ func TestSumPairedDurationsWithNow_Unordered(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func TestSameDay(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b time.Time
+		want bool
+	}{
+		{"same time", t8am, t8am, true},
+		{"same day", t8am, t4pm, true},
+		{"next day", t8am, t8am.AddDate(0, 0, 1), false},
+		{"same day next year", t8am, t8am.AddDate(1, 0, 0), false},
+		{"midnight boundary", time.Date(2025, 1, 1, 23, 59, 0, 0, time.UTC), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameDay(tt.a, tt.b); got != tt.want {
+				t.Errorf("SameDay(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	got := StartOfDay(time.Date(2025, 1, 1, 16, 42, 13, 5, time.UTC))
+	want := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("StartOfDay() = %v, want %v", got, want)
+	}
+}
+
+func TestDurations_SpansAndBreaks(t *testing.T) {
+	tests := []struct {
+		name   string
+		times  Durations
+		spans  []Span
+		breaks []Span
+	}{
+		{
+			name:   "empty",
+			times:  Durations{},
+			spans:  []Span{},
+			breaks: []Span{},
+		},
+		{
+			name:   "open span",
+			times:  Durations{t8am},
+			spans:  []Span{},
+			breaks: []Span{},
+		},
+		{
+			name:   "single pair",
+			times:  Durations{t8am, t10am},
+			spans:  []Span{{t8am, t10am}},
+			breaks: []Span{},
+		},
+		{
+			name:   "break and open span",
+			times:  Durations{t8am, t10am, t12pm},
+			spans:  []Span{{t8am, t10am}},
+			breaks: []Span{{t10am, t12pm}},
+		},
+		{
+			name:   "two pairs",
+			times:  Durations{t8am, t10am, t12pm, t4pm},
+			spans:  []Span{{t8am, t10am}, {t12pm, t4pm}},
+			breaks: []Span{{t10am, t12pm}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.times.Spans(); !reflect.DeepEqual(got, tt.spans) {
+				t.Errorf("Spans() = %v, want %v", got, tt.spans)
+			}
+			if got := tt.times.Breaks(); !reflect.DeepEqual(got, tt.breaks) {
+				t.Errorf("Breaks() = %v, want %v", got, tt.breaks)
+			}
+		})
+	}
+}
+
+func TestFormatDurationSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00:00"},
+		{59 * time.Second, "00:00:59"},
+		{time.Hour + 2*time.Minute + 3*time.Second + 900*time.Millisecond, "01:02:03"},
+		{-(90 * time.Second), "-00:01:30"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDurationSeconds(tt.d); got != tt.want {
+			t.Errorf("FormatDurationSeconds(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestDurations_BreakDuration(t *testing.T) {
+	t1pm := time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		durations Durations
+		now       time.Time
+		want      time.Duration
+	}{
+		{"empty", Durations{}, t4pm, 0},
+		{"working", Durations{t8am}, t4pm, 0},
+		{"one break", Durations{t8am, t10am, t12pm}, t4pm, 2 * time.Hour},
+		{"ongoing break", Durations{t8am, t10am, t12pm, t1pm}, t4pm, 5 * time.Hour},
+		{"ongoing break ignored", Durations{t8am, t10am, t12pm, t1pm}, time.Time{}, 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.durations.BreakDuration(tt.now); got != tt.want {
+				t.Errorf("BreakDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
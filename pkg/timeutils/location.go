@@ -0,0 +1,13 @@
+package timeutils
+
+import (
+	"time"
+)
+
+// SameOffset reports whether both locations currently have the same offset
+// from UTC, i.e. whether they show the same wall clock time at t.
+func SameOffset(a, b *time.Location, t time.Time) bool {
+	_, ao := t.In(a).Zone()
+	_, bo := t.In(b).Zone()
+	return ao == bo
+}
@@ -0,0 +1,17 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSameOffset(t *testing.T) {
+	now := time.Now()
+	a := time.FixedZone("A", 3600)
+	if !SameOffset(a, time.FixedZone("B", 3600), now) {
+		t.Error("locations with the same offset should match")
+	}
+	if SameOffset(a, time.UTC, now) {
+		t.Error("locations with different offsets should not match")
+	}
+}
@@ -0,0 +1,84 @@
+package timeutils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LunchWindow describes when and for how long lunch breaks usually happen. It is
+// used to recognize an unlogged lunch break among the gaps of activity.
+type LunchWindow struct {
+	// From and To bound the window, as offsets from midnight.
+	From time.Duration
+	To   time.Duration
+	// Min and Max bound the duration of a lunch break.
+	Min time.Duration
+	Max time.Duration
+	// Threshold is the confidence from which a gap is considered a lunch break.
+	Threshold float64
+}
+
+// DefaultLunchWindow recognizes 30 to 90 minutes breaks taken mostly between
+// 11:30 and 14:00.
+var DefaultLunchWindow = LunchWindow{
+	From:      11*time.Hour + 30*time.Minute,
+	To:        14 * time.Hour,
+	Min:       30 * time.Minute,
+	Max:       90 * time.Minute,
+	Threshold: 0.5,
+}
+
+// ParseLunchWindow parses a "HH:MM-HH:MM" window, each bound in any format accepted
+// by ParseTime. The other settings are taken from DefaultLunchWindow.
+func ParseLunchWindow(s string) (LunchWindow, error) {
+	fromStr, toStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return LunchWindow{}, fmt.Errorf("%s is not a HH:MM-HH:MM window", s)
+	}
+	from, err := ParseTime(strings.TrimSpace(fromStr))
+	if err != nil {
+		return LunchWindow{}, err
+	}
+	to, err := ParseTime(strings.TrimSpace(toStr))
+	if err != nil {
+		return LunchWindow{}, err
+	}
+	if !to.After(from) {
+		return LunchWindow{}, fmt.Errorf("the end of the window %s must be after its start", s)
+	}
+
+	w := DefaultLunchWindow
+	w.From = from.Sub(StartOfDay(from))
+	w.To = to.Sub(StartOfDay(to))
+	return w, nil
+}
+
+// Confidence returns how likely the gap is a lunch break, between 0 and 1: the
+// share of the gap falling inside the window. Gaps shorter than Min or longer
+// than Max are never lunch breaks and get a confidence of 0.
+func (w LunchWindow) Confidence(gap Span) float64 {
+	d := gap.Duration()
+	if d < w.Min || d > w.Max || d <= 0 {
+		return 0
+	}
+
+	day := StartOfDay(gap.Start)
+	from, to := day.Add(w.From), day.Add(w.To)
+	start, end := gap.Start, gap.End
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return float64(end.Sub(start)) / float64(d)
+}
+
+// IsLunch reports whether the gap is confidently a lunch break.
+func (w LunchWindow) IsLunch(gap Span) bool {
+	return w.Confidence(gap) >= w.Threshold
+}
@@ -0,0 +1,61 @@
+package timeutils
+
+import (
+	"testing"
+	"time"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestLunchWindow_Confidence(t *testing.T) {
+	tests := []struct {
+		name string
+		gap  Span
+		want float64
+	}{
+		{"inside window", Span{at(12, 0), at(12, 45)}, 1},
+		{"half outside", Span{at(11, 0), at(12, 0)}, 0.5},
+		{"outside window", Span{at(9, 0), at(10, 0)}, 0},
+		{"too short", Span{at(12, 0), at(12, 15)}, 0},
+		{"too long", Span{at(11, 30), at(14, 0)}, 0},
+		{"reversed", Span{at(12, 45), at(12, 0)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultLunchWindow.Confidence(tt.gap); got != tt.want {
+				t.Errorf("Confidence(%v) = %v, want %v", tt.gap, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLunchWindow_IsLunch(t *testing.T) {
+	if !DefaultLunchWindow.IsLunch(Span{at(12, 0), at(12, 45)}) {
+		t.Error("a 45 minutes break at noon should be a lunch break")
+	}
+	if DefaultLunchWindow.IsLunch(Span{at(10, 0), at(11, 45)}) {
+		t.Error("a break mostly before the window should not be a lunch break")
+	}
+}
+
+func TestParseLunchWindow(t *testing.T) {
+	w, err := ParseLunchWindow("11:00-13:30")
+	if err != nil {
+		t.Fatalf("ParseLunchWindow returned error: %v", err)
+	}
+	if w.From != 11*time.Hour || w.To != 13*time.Hour+30*time.Minute {
+		t.Fatalf("window = %v-%v, want 11h-13h30m", w.From, w.To)
+	}
+	if w.Min != DefaultLunchWindow.Min || w.Max != DefaultLunchWindow.Max {
+		t.Fatalf("durations = %v-%v, want defaults", w.Min, w.Max)
+	}
+
+	for _, invalid := range []string{"", "11:00", "13:00-11:00", "25:00-26:00"} {
+		if _, err := ParseLunchWindow(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
@@ -27,10 +27,17 @@ var (
 //   - "730", "7:30", "0730" -> 07:30
 //
 // The input may contain only digits and an optional single ":" separator.
-// An error is returned for invalid formats or out-of-range hour/minute values.
+// An error is returned for invalid formats or out-of-range hour/minute values,
+// its message starts with the input: "25:00: hours out of range (0-23)".
 func ParseTime(timeStr string) (time.Time, error) {
+	return ParseTimeOn(timeStr, time.Now())
+}
+
+// ParseTimeOn parses a time like ParseTime, on the day of day and in its location.
+func ParseTimeOn(timeStr string, day time.Time) (time.Time, error) {
+	input := timeStr
 	if !validTimeFormat.MatchString(timeStr) {
-		return time.Time{}, fmt.Errorf("%s is not a supported time format: ", timeStr)
+		return time.Time{}, fmt.Errorf("%s: not a time, use H, HHMM or HH:MM", input)
 	}
 
 	// Normalize by removing colon
@@ -46,25 +53,45 @@ func ParseTime(timeStr string) (time.Time, error) {
 	case 4:
 		// already HHMM
 	default:
-		return time.Time{}, fmt.Errorf("unsupported time format length: %d", len(timeStr))
+		return time.Time{}, fmt.Errorf("%s: unsupported time format length %d", input, len(timeStr))
 	}
 
 	hours, err := strconv.Atoi(timeStr[:2])
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid hours: %w", err)
+		return time.Time{}, fmt.Errorf("%s: invalid hours: %w", input, err)
 	}
 	minutes, err := strconv.Atoi(timeStr[2:])
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid minutes: %w", err)
+		return time.Time{}, fmt.Errorf("%s: invalid minutes: %w", input, err)
 	}
 
 	if hours < 0 || hours > 23 {
-		return time.Time{}, fmt.Errorf("hours out of range (0-23): %d", hours)
+		return time.Time{}, fmt.Errorf("%s: hours out of range (0-23)", input)
 	}
 	if minutes < 0 || minutes > 59 {
-		return time.Time{}, fmt.Errorf("minutes out of range (0-50): %d", minutes)
+		return time.Time{}, fmt.Errorf("%s: minutes out of range (0-59)", input)
 	}
 
-	now := time.Now()
-	return time.Date(now.Year(), now.Month(), now.Day(), hours, minutes, 0, 0, time.Local), nil
+	return time.Date(day.Year(), day.Month(), day.Day(), hours, minutes, 0, 0, day.Location()), nil
+}
+
+// ParseDate parses a day relative to now, "today" or "yesterday", or written as
+// YYYY-MM-DD. The returned time is the start of the day, in the location of now.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	switch strings.ToLower(s) {
+	case "today":
+		return StartOfDay(now), nil
+	case "yesterday":
+		return StartOfDay(now).AddDate(0, 0, -1), nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: not a date, use today, yesterday or YYYY-MM-DD", s)
+	}
+	return day, nil
+}
+
+// OnDay returns the time of day of t on the day of day.
+func OnDay(t, day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), t.Second(), 0, day.Location())
 }
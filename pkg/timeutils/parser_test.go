@@ -2,6 +2,7 @@ package timeutils
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseTime_ValidExamples(t *testing.T) {
@@ -30,11 +31,66 @@ func TestParseTime_ValidExamples(t *testing.T) {
 	}
 }
 
+func TestParseTimeOn(t *testing.T) {
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	day := time.Date(2025, 3, 14, 23, 30, 0, 0, tokyo)
+	got, err := ParseTimeOn("8:00", day)
+	if err != nil {
+		t.Fatalf("ParseTimeOn returned error: %v", err)
+	}
+	if want := time.Date(2025, 3, 14, 8, 0, 0, 0, tokyo); !got.Equal(want) || got.Location() != tokyo {
+		t.Fatalf("ParseTimeOn(8:00) = %v, want %v", got, want)
+	}
+}
+
 func TestParseTime_Invalid(t *testing.T) {
-	invalid := []string{"14a00", "25:00", "14:60", ""}
-	for _, s := range invalid {
-		if _, err := ParseTime(s); err == nil {
-			t.Fatalf("expected error for %q", s)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"14a00", "14a00: not a time, use H, HHMM or HH:MM"},
+		{"25:00", "25:00: hours out of range (0-23)"},
+		{"14:60", "14:60: minutes out of range (0-59)"},
+		{"", ": not a time, use H, HHMM or HH:MM"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTime(tt.input)
+		if err == nil {
+			t.Fatalf("expected error for %q", tt.input)
+		}
+		if err.Error() != tt.want {
+			t.Fatalf("ParseTime(%q) error = %q, want %q", tt.input, err, tt.want)
 		}
 	}
 }
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 30, 0, 0, time.Local)
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"today", "2025-01-01 00:00", false},
+		{"Yesterday", "2024-12-31 00:00", false},
+		{"2024-12-24", "2024-12-24 00:00", false},
+		{"24.12.2024", "", true},
+		{"tomorrow", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDate(tt.input, now)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if !tt.wantErr && got.Format("2006-01-02 15:04") != tt.want {
+			t.Fatalf("ParseDate(%q) = %s, want %s", tt.input, got.Format("2006-01-02 15:04"), tt.want)
+		}
+	}
+
+	// The days are in the location of now
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	if got, _ := ParseDate("2024-12-24", now.In(tokyo)); got.Location() != tokyo {
+		t.Fatalf("ParseDate in Tokyo returned a day in %s", got.Location())
+	}
+}
@@ -0,0 +1,260 @@
+// Package tracking models the entries recorded during a day: the times at which
+// the user clocked in or out, along with what they describe.
+package tracking
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// Entry is a clock in or clock out, optionally labelled with a short note (e.g. "standup").
+// The time of a span is booked on the project of the entry opening it, and is billable
+// when that entry is.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Note     string    `json:"note,omitempty"`
+	Project  string    `json:"project,omitempty"`
+	Billable bool      `json:"billable,omitempty"`
+}
+
+// Entries is an ordered collection of entries, in ascending chronological order.
+// Operations never modify the receiver and return a new collection instead.
+type Entries []Entry
+
+// FromTimes builds entries without notes from a collection of times.
+func FromTimes(times timeutils.Durations) Entries {
+	entries := make(Entries, len(times))
+	for i, t := range times {
+		entries[i] = Entry{Time: t}
+	}
+	return entries.sorted()
+}
+
+// sorted orders the entries chronologically, entries at the same time keep their order.
+func (entries Entries) sorted() Entries {
+	slices.SortStableFunc(entries, func(a, b Entry) int { return a.Time.Compare(b.Time) })
+	return entries
+}
+
+// Times returns the times of the entries, in chronological order.
+func (entries Entries) Times() timeutils.Durations {
+	times := make(timeutils.Durations, len(entries))
+	for i, e := range entries {
+		times[i] = e.Time
+	}
+	return times
+}
+
+// Last returns the last entry, or the zero Entry if there are none.
+func (entries Entries) Last() Entry {
+	if len(entries) == 0 {
+		return Entry{}
+	}
+	return entries[len(entries)-1]
+}
+
+// Add returns a copy of the collection with e inserted at its chronological position.
+func (entries Entries) Add(e Entry) Entries {
+	return append(slices.Clone(entries), e).sorted()
+}
+
+// Remove returns a copy of the collection without the entry at index i.
+// If the index is out of bounds, the collection is returned unchanged.
+func (entries Entries) Remove(i int) Entries {
+	if i < 0 || i >= len(entries) {
+		return entries
+	}
+	return slices.Delete(slices.Clone(entries), i, i+1)
+}
+
+// Replace returns a copy of the collection in which the entry at index i is replaced by e,
+// moved to its chronological position. If the index is out of bounds, e is simply added.
+func (entries Entries) Replace(i int, e Entry) Entries {
+	return entries.Remove(i).Add(e)
+}
+
+// Annotate returns a copy of the collection in which the entry at index i carries note.
+// If the index is out of bounds, the collection is returned unchanged.
+func (entries Entries) Annotate(i int, note string) Entries {
+	if i < 0 || i >= len(entries) {
+		return entries
+	}
+	annotated := slices.Clone(entries)
+	annotated[i].Note = strings.TrimSpace(note)
+	return annotated
+}
+
+// Index returns the index of the first entry at time t, or -1 if there is none.
+func (entries Entries) Index(t time.Time) int {
+	return slices.IndexFunc(entries, func(e Entry) bool { return e.Time.Equal(t) })
+}
+
+// Equal reports whether both collections hold the same instants with the same notes and projects.
+func (entries Entries) Equal(other Entries) bool {
+	return slices.EqualFunc(entries, other, Entry.equal)
+}
+
+// equal reports whether both entries are at the same instant with the same note and project.
+func (e Entry) equal(other Entry) bool {
+	return e.Time.Equal(other.Time) && e.Note == other.Note && e.Project == other.Project && e.Billable == other.Billable
+}
+
+// Merge returns theirs with the changes made from base to ours: the entries ours added
+// are added and the ones it removed are removed, so that both sides keep their
+// changes. An entry changed by ours is removed, then added as it now is.
+func Merge(base, ours, theirs Entries) Entries {
+	merged := slices.Clone(theirs)
+	for _, e := range base.without(ours) {
+		if i := slices.IndexFunc(merged, e.equal); i >= 0 {
+			merged = slices.Delete(merged, i, i+1)
+		}
+	}
+	for _, e := range ours.without(base) {
+		merged = merged.Add(e)
+	}
+	return merged
+}
+
+// without returns the entries which are not in other, an entry of other matching a
+// single entry.
+func (entries Entries) without(other Entries) Entries {
+	rest := slices.Clone(other)
+	var missing Entries
+	for _, e := range entries {
+		if i := slices.IndexFunc(rest, e.equal); i >= 0 {
+			rest = slices.Delete(rest, i, i+1)
+		} else {
+			missing = append(missing, e)
+		}
+	}
+	return missing
+}
+
+// ToggleBillable returns a copy of the collection in which the span holding the entry at
+// index i changed from billable to non-billable or the other way round.
+// If the index is out of bounds, the collection is returned unchanged.
+func (entries Entries) ToggleBillable(i int) Entries {
+	if i < 0 || i >= len(entries) {
+		return entries
+	}
+	toggled := slices.Clone(entries)
+	open := i - i%2
+	toggled[open].Billable = !toggled[open].Billable
+	return toggled
+}
+
+// ProjectTotals returns the time worked on each project, spans without project are
+// booked on "". The open span runs until now, a zero now leaves it out.
+func (entries Entries) ProjectTotals(now time.Time) map[string]time.Duration {
+	totals := make(map[string]time.Duration)
+	entries.eachSpan(now, func(open Entry, d time.Duration) {
+		totals[open.Project] += d
+	})
+	return totals
+}
+
+// BillableTotals returns the time worked on billable and non-billable spans.
+// The open span runs until now, a zero now leaves it out.
+func (entries Entries) BillableTotals(now time.Time) (billable, nonBillable time.Duration) {
+	entries.eachSpan(now, func(open Entry, d time.Duration) {
+		if open.Billable {
+			billable += d
+		} else {
+			nonBillable += d
+		}
+	})
+	return billable, nonBillable
+}
+
+// eachSpan calls fn with the entry opening each span and its duration, the open span
+// running until now. Spans of a zero or negative duration are skipped.
+func (entries Entries) eachSpan(now time.Time, fn func(open Entry, d time.Duration)) {
+	for i := 0; i < len(entries); i += 2 {
+		end := now
+		if i+1 < len(entries) {
+			end = entries[i+1].Time
+		}
+		if end.IsZero() {
+			continue
+		}
+		if d := end.Sub(entries[i].Time); d > 0 {
+			fn(entries[i], d)
+		}
+	}
+}
+
+// ParseEntry parses an input made of a time, in any format accepted by timeutils.ParseTime,
+// optionally followed by a note: "9:05 standup". The time is on the day of now and in its
+// location, unless it is prefixed by a date accepted by timeutils.ParseDate: "yesterday 17:30".
+func ParseEntry(s string, now time.Time) (Entry, error) {
+	day, rest, err := cutDate(strings.TrimSpace(s), now)
+	if err != nil {
+		return Entry{}, err
+	}
+	value, note, _ := strings.Cut(rest, " ")
+	t, err := timeutils.ParseTimeOn(value, day)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{Time: t, Note: strings.TrimSpace(note)}, nil
+}
+
+// ParseEntries parses an input holding several entries separated by spaces or commas:
+// "8:00 12:00 lunch, 12:45". Words which are not times form the note of the time before them.
+// Each part between commas may start with a date, as accepted by ParseEntry.
+func ParseEntries(s string, now time.Time) (Entries, error) {
+	var entries Entries
+	for _, part := range strings.Split(s, ",") {
+		day, rest, err := cutDate(strings.TrimSpace(part), now)
+		if err != nil {
+			return nil, err
+		}
+		words := strings.Fields(rest)
+		for len(words) > 0 {
+			t, err := timeutils.ParseTimeOn(words[0], day)
+			if err != nil {
+				return nil, err
+			}
+			words = words[1:]
+			n := 0
+			for n < len(words) && !looksLikeTime(words[n]) {
+				n++
+			}
+			entries = append(entries, Entry{Time: t, Note: strings.Join(words[:n], " ")})
+			words = words[n:]
+		}
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no time entered")
+	}
+	return entries.sorted(), nil
+}
+
+// cutDate splits the date prefixing s, if any. The returned day is now without date.
+func cutDate(s string, now time.Time) (time.Time, string, error) {
+	first, rest, _ := strings.Cut(s, " ")
+	if !looksLikeDate(first) {
+		return now, s, nil
+	}
+	day, err := timeutils.ParseDate(first, now)
+	return day, strings.TrimSpace(rest), err
+}
+
+// looksLikeTime reports whether s is made of digits and colons only, such words are
+// times rather than notes even when invalid so that typos are reported.
+func looksLikeTime(s string) bool {
+	return strings.Trim(s, "0123456789:") == "" && strings.ContainsAny(s, "0123456789")
+}
+
+// looksLikeDate reports whether s is meant as a date, see timeutils.ParseDate.
+func looksLikeDate(s string) bool {
+	switch strings.ToLower(s) {
+	case "today", "yesterday":
+		return true
+	}
+	return strings.Trim(s, "0123456789-") == "" && strings.Contains(s, "-")
+}
@@ -0,0 +1,272 @@
+package tracking
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2025, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestEntries_Add(t *testing.T) {
+	entries := Entries{{Time: at(8, 0)}, {Time: at(12, 0)}}
+	added := entries.Add(Entry{Time: at(10, 0), Note: "standup"})
+
+	if len(entries) != 2 {
+		t.Fatalf("Add modified the receiver: %v", entries)
+	}
+	want := Entries{{Time: at(8, 0)}, {Time: at(10, 0), Note: "standup"}, {Time: at(12, 0)}}
+	if !added.Equal(want) {
+		t.Errorf("Add() = %v, want %v", added, want)
+	}
+}
+
+func TestEntries_Remove(t *testing.T) {
+	entries := Entries{{Time: at(8, 0)}, {Time: at(10, 0)}, {Time: at(12, 0)}}
+
+	tests := []struct {
+		name  string
+		index int
+		want  Entries
+	}{
+		{"first", 0, Entries{{Time: at(10, 0)}, {Time: at(12, 0)}}},
+		{"last", 2, Entries{{Time: at(8, 0)}, {Time: at(10, 0)}}},
+		{"out of bounds", 3, entries},
+		{"negative", -1, entries},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entries.Remove(tt.index); !got.Equal(tt.want) {
+				t.Errorf("Remove(%d) = %v, want %v", tt.index, got, tt.want)
+			}
+		})
+	}
+	if len(entries) != 3 || !entries[1].Time.Equal(at(10, 0)) {
+		t.Fatalf("Remove modified the receiver: %v", entries)
+	}
+}
+
+func TestEntries_Replace(t *testing.T) {
+	entries := Entries{{Time: at(8, 0), Note: "early"}, {Time: at(12, 0)}}
+	got := entries.Replace(0, Entry{Time: at(13, 0), Note: "late"})
+	want := Entries{{Time: at(12, 0)}, {Time: at(13, 0), Note: "late"}}
+	if !got.Equal(want) {
+		t.Errorf("Replace() = %v, want %v", got, want)
+	}
+}
+
+func TestEntries_Annotate(t *testing.T) {
+	entries := Entries{{Time: at(8, 0)}, {Time: at(12, 0)}}
+	got := entries.Annotate(1, "  doctor ")
+	if got[1].Note != "doctor" {
+		t.Errorf("note = %q, want %q", got[1].Note, "doctor")
+	}
+	if entries[1].Note != "" {
+		t.Fatalf("Annotate modified the receiver: %v", entries)
+	}
+	if out := entries.Annotate(5, "nope"); !out.Equal(entries) {
+		t.Errorf("Annotate out of bounds = %v, want unchanged", out)
+	}
+}
+
+func TestEntries_Equal(t *testing.T) {
+	a := Entries{{Time: at(8, 0), Note: "standup"}}
+	if !a.Equal(Entries{{Time: at(8, 0), Note: "standup"}}) {
+		t.Error("identical entries should be equal")
+	}
+	if a.Equal(Entries{{Time: at(8, 0)}}) {
+		t.Error("entries with different notes should differ")
+	}
+	if a.Equal(Entries{{Time: at(8, 1), Note: "standup"}}) {
+		t.Error("entries with different times should differ")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := Entries{{Time: at(8, 0)}, {Time: at(12, 0)}}
+	tests := []struct {
+		name         string
+		ours, theirs Entries
+		want         Entries
+	}{
+		{"unchanged", base, base, base},
+		{"ours only", Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}, base,
+			Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}},
+		{"theirs only", base, Entries{{Time: at(7, 30)}, {Time: at(8, 0)}, {Time: at(12, 0)}},
+			Entries{{Time: at(7, 30)}, {Time: at(8, 0)}, {Time: at(12, 0)}}},
+		{"both added", Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}, Entries{{Time: at(7, 0)}, {Time: at(7, 30)}, {Time: at(8, 0)}, {Time: at(12, 0)}},
+			Entries{{Time: at(7, 0)}, {Time: at(7, 30)}, {Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}},
+		{"ours removed, theirs added", Entries{{Time: at(8, 0)}}, Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}},
+			Entries{{Time: at(8, 0)}, {Time: at(13, 0)}}},
+		{"ours annotated", Entries{{Time: at(8, 0), Note: "standup"}, {Time: at(12, 0)}}, Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}},
+			Entries{{Time: at(8, 0), Note: "standup"}, {Time: at(12, 0)}, {Time: at(13, 0)}}},
+		{"removed by both", Entries{{Time: at(8, 0)}}, Entries{{Time: at(8, 0)}}, Entries{{Time: at(8, 0)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merge(base, tt.ours, tt.theirs); !got.Equal(tt.want) {
+				t.Errorf("Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntries_ProjectTotals(t *testing.T) {
+	entries := Entries{
+		{Time: at(8, 0), Project: "acme"},
+		{Time: at(10, 0)},
+		{Time: at(10, 0), Project: "internal"},
+		{Time: at(12, 0)},
+		{Time: at(13, 0), Project: "acme"},
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want map[string]time.Duration
+	}{
+		{"open span running", at(14, 30), map[string]time.Duration{"acme": 3*time.Hour + 30*time.Minute, "internal": 2 * time.Hour}},
+		{"open span left out", time.Time{}, map[string]time.Duration{"acme": 2 * time.Hour, "internal": 2 * time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entries.ProjectTotals(tt.now); !maps.Equal(got, tt.want) {
+				t.Errorf("ProjectTotals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (Entries{{Time: at(8, 0)}, {Time: at(9, 0)}}).ProjectTotals(time.Time{}); got[""] != time.Hour {
+		t.Errorf("ProjectTotals() without project = %v, want 1h on \"\"", got)
+	}
+}
+
+func TestEntries_ToggleBillable(t *testing.T) {
+	entries := Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0), Billable: true}}
+
+	tests := []struct {
+		name  string
+		index int
+		want  Entries
+	}{
+		{"opening entry", 0, Entries{{Time: at(8, 0), Billable: true}, {Time: at(12, 0)}, {Time: at(13, 0), Billable: true}}},
+		{"closing entry", 1, Entries{{Time: at(8, 0), Billable: true}, {Time: at(12, 0)}, {Time: at(13, 0), Billable: true}}},
+		{"open span", 2, Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}}},
+		{"out of bounds", 3, entries},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entries.ToggleBillable(tt.index); !got.Equal(tt.want) {
+				t.Errorf("ToggleBillable(%d) = %v, want %v", tt.index, got, tt.want)
+			}
+		})
+	}
+	if entries[0].Billable {
+		t.Errorf("ToggleBillable modified the receiver: %v", entries)
+	}
+}
+
+func TestEntries_BillableTotals(t *testing.T) {
+	entries := Entries{
+		{Time: at(8, 0), Billable: true},
+		{Time: at(12, 0)},
+		{Time: at(13, 0)},
+		{Time: at(14, 0)},
+		{Time: at(14, 0), Billable: true},
+	}
+	billable, nonBillable := entries.BillableTotals(at(15, 30))
+	if billable != 5*time.Hour+30*time.Minute || nonBillable != time.Hour {
+		t.Errorf("BillableTotals() = %v, %v, want 5h30m, 1h", billable, nonBillable)
+	}
+}
+
+func TestParseEntry(t *testing.T) {
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		input    string
+		wantTime string
+		wantNote string
+		wantErr  bool
+	}{
+		{"9:05", "09:05", "", false},
+		{"905 standup", "09:05", "standup", false},
+		{"  14:00   doctor appointment ", "14:00", "doctor appointment", false},
+		{"standup", "", "", true},
+		{"", "", "", true},
+		{"2025-01-03 8:15 badge", "2025-01-03 08:15", "badge", false},
+		{"2025-13-03 8:15", "", "", true},
+		{"yesterday 17:30", "2025-03-13 17:30", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEntry(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEntry(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			format := "15:04"
+			if len(tt.wantTime) > len(format) {
+				format = "2006-01-02 15:04"
+			}
+			if got.Time.Format(format) != tt.wantTime || got.Note != tt.wantNote {
+				t.Errorf("ParseEntry(%q) = %s %q, want %s %q", tt.input, got.Time.Format(format), got.Note, tt.wantTime, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestParseEntries(t *testing.T) {
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.Local)
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"8:00", "08:00", false},
+		{"8:00 12:00 12:45", "08:00 12:00 12:45", false},
+		{"1245, 800,1200", "08:00 12:00 12:45", false},
+		{"800 1200 lunch break, 1245", "08:00 12:00(lunch break) 12:45", false},
+		{"905 standup 1000", "09:05(standup) 10:00", false},
+		{"standup 905", "", true},
+		{"800 25:00", "", true},
+		{" , ", "", true},
+		{"1700 leaving early today", "17:00(leaving early today)", false},
+		{"2025-01-03 1730, 815", "2025-01-03T17:30 08:15", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEntries(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEntries(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var parts []string
+			for _, e := range got {
+				part := e.Time.Format("15:04")
+				if !timeutils.SameDay(e.Time, now) {
+					part = e.Time.Format("2006-01-02T15:04")
+				}
+				if e.Note != "" {
+					part += "(" + e.Note + ")"
+				}
+				parts = append(parts, part)
+			}
+			if s := strings.Join(parts, " "); s != tt.want {
+				t.Errorf("ParseEntries(%q) = %s, want %s", tt.input, s, tt.want)
+			}
+		})
+	}
+}
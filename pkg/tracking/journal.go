@@ -0,0 +1,122 @@
+package tracking
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// journalFile is the name of the journal within the directory of a Store.
+	journalFile = "journal.jsonl"
+	// journalSize is the number of changes kept in the journal, the oldest are
+	// forgotten first.
+	journalSize = 100
+)
+
+// ErrNothingToUndo is returned by Undo when the journal is empty.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// journalRecord is a change of the journal, a JSON line holding the content of the
+// file of the day before the change, null when the file did not exist.
+type journalRecord struct {
+	Date     string          `json:"date"`
+	At       time.Time       `json:"at"`
+	Previous json.RawMessage `json:"previous"`
+}
+
+// Change describes a change undone, see Undo.
+type Change struct {
+	// Day is the day changed, at midnight in the local time zone
+	Day time.Time
+	// At is when the change was made
+	At time.Time
+}
+
+// Undo reverts the last change saved, restoring the file of its day, and forgets it
+// so that the change before can be undone next. It returns ErrNothingToUndo when no
+// change is left.
+func (s Store) Undo() (Change, error) {
+	records, err := s.journal()
+	if err != nil {
+		return Change{}, err
+	}
+	if len(records) == 0 {
+		return Change{}, ErrNothingToUndo
+	}
+	last := records[len(records)-1]
+	day, err := time.ParseInLocation(dayLayout, last.Date, s.location())
+	if err != nil {
+		return Change{}, fmt.Errorf("%s: %w", filepath.Join(s.Dir, journalFile), err)
+	}
+
+	path := s.path(day)
+	if len(last.Previous) == 0 || bytes.Equal(last.Previous, []byte("null")) {
+		err = os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	} else {
+		// The journal keeps the content compacted, it is written back as Save does
+		var b bytes.Buffer
+		if err = json.Indent(&b, last.Previous, "", "  "); err == nil {
+			err = writeFile(path, append(b.Bytes(), '\n'))
+		}
+	}
+	if err != nil {
+		return Change{}, err
+	}
+	return Change{Day: day, At: last.At.In(s.location())}, s.writeJournal(records[:len(records)-1])
+}
+
+// record appends a change to the journal.
+func (s Store) record(r journalRecord) error {
+	records, err := s.journal()
+	if err != nil {
+		return err
+	}
+	records = append(records, r)
+	return s.writeJournal(records[max(len(records)-journalSize, 0):])
+}
+
+// journal returns the changes of the journal, the oldest first.
+func (s Store) journal() ([]journalRecord, error) {
+	f, err := os.Open(filepath.Join(s.Dir, journalFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []journalRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var r journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// writeJournal replaces the changes of the journal.
+func (s Store) writeJournal(records []journalRecord) error {
+	var b bytes.Buffer
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		b.Write(append(line, '\n'))
+	}
+	return writeFile(filepath.Join(s.Dir, journalFile), b.Bytes())
+}
@@ -0,0 +1,182 @@
+package tracking
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// dayLayout names the file of a day in a Store.
+const dayLayout = "2006-01-02"
+
+// Store keeps the entries of each day in a JSON file of its directory, named after
+// the day: 2006-01-02.json.
+type Store struct {
+	Dir string
+	// Location is the time zone the days are counted in, the local one when nil
+	Location *time.Location
+}
+
+// Day is what a Store keeps of a day.
+type Day struct {
+	Entries Entries
+	// Target is the daily target the day was tracked with, zero when unknown
+	Target time.Duration
+	// Metadata holds the key-value pairs attached to the day, e.g. by hooks: a
+	// mood, the standup notes or ticket ids
+	Metadata map[string]string
+}
+
+// storedDay is the content of the file of a day.
+type storedDay struct {
+	Entries       Entries           `json:"entries"`
+	TargetSeconds int64             `json:"target_seconds,omitempty"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// location returns the time zone the days are counted in.
+func (s Store) location() *time.Location {
+	if s.Location == nil {
+		return time.Local
+	}
+	return s.Location
+}
+
+// path returns the file of the day of t.
+func (s Store) path(t time.Time) string {
+	return filepath.Join(s.Dir, t.Format(dayLayout)+".json")
+}
+
+// Load returns what is stored of the day of t, an empty day when nothing was stored.
+func (s Store) Load(t time.Time) (Day, error) {
+	b, err := os.ReadFile(s.path(t))
+	if errors.Is(err, os.ErrNotExist) {
+		return Day{Entries: Entries{}}, nil
+	}
+	if err != nil {
+		return Day{}, err
+	}
+	var stored storedDay
+	if err := json.Unmarshal(b, &stored); err != nil {
+		return Day{}, fmt.Errorf("%s: %w", s.path(t), err)
+	}
+	entries := make(Entries, len(stored.Entries))
+	for i, e := range stored.Entries {
+		// Stored with their offset, displayed in the time zone of the store
+		e.Time = e.Time.In(s.location())
+		entries[i] = e
+	}
+	return Day{Entries: entries.sorted(), Target: time.Duration(stored.TargetSeconds) * time.Second, Metadata: stored.Metadata}, nil
+}
+
+// Save replaces what is stored of the day of t. The file is replaced at once, so
+// that readers never see it half written. The previous content is recorded in the
+// journal of the store, so that the change can be undone, see Undo.
+func (s Store) Save(t time.Time, day Day) error {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+	stored := storedDay{Entries: day.Entries, TargetSeconds: int64(day.Target.Seconds()), Metadata: day.Metadata}
+	if stored.Entries == nil {
+		stored.Entries = Entries{}
+	}
+	b, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	path := s.path(t)
+	previous, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		previous = nil
+	case err != nil:
+		return err
+	case bytes.Equal(previous, b):
+		// Nothing to journal, e.g. a tracker saving what it just loaded
+		return nil
+	}
+	if err := writeFile(path, b); err != nil {
+		return err
+	}
+	return s.record(journalRecord{Date: t.Format(dayLayout), At: time.Now(), Previous: previous})
+}
+
+// writeFile replaces the file at path with b at once, through a temporary file.
+func writeFile(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".timely-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Days returns the days stored, in chronological order, at midnight in the time
+// zone of the store.
+func (s Store) Days() ([]time.Time, error) {
+	files, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var days []time.Time
+	for _, f := range files {
+		name, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() {
+			continue
+		}
+		if day, err := time.ParseInLocation(dayLayout, name, s.location()); err == nil {
+			days = append(days, day)
+		}
+	}
+	// Names sort chronologically
+	return days, nil
+}
+
+// Watch reports the day of t on the returned channel whenever its file is changed,
+// e.g. by another process. The file is checked every interval.
+func (s Store) Watch(t time.Time, interval time.Duration) <-chan Day {
+	changes := make(chan Day)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := s.modified(t)
+		for range ticker.C {
+			modified := s.modified(t)
+			if modified.Equal(last) {
+				continue
+			}
+			last = modified
+			if day, err := s.Load(t); err == nil {
+				changes <- day
+			}
+		}
+	}()
+	return changes
+}
+
+// modified returns when the file of the day of t last changed, zero when missing.
+func (s Store) modified(t time.Time) time.Time {
+	info, err := os.Stat(s.path(t))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
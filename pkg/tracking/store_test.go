@@ -0,0 +1,146 @@
+package tracking
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore_SaveLoad(t *testing.T) {
+	store := Store{Dir: filepath.Join(t.TempDir(), "days")}
+	day := at(0, 0)
+
+	empty, err := store.Load(day)
+	if err != nil || len(empty.Entries) != 0 || empty.Target != 0 {
+		t.Fatalf("Load() of a day never saved = %v, %v, want an empty day", empty, err)
+	}
+
+	saved := Day{
+		Entries:  Entries{{Time: at(8, 0), Note: "standup", Project: "acme", Billable: true}, {Time: at(12, 0)}},
+		Target:   7*time.Hour + 30*time.Minute,
+		Metadata: map[string]string{"mood": "good", "ticket": "ABC-12"},
+	}
+	if err := store.Save(day, saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store.Dir, "2025-01-01.json")); err != nil {
+		t.Errorf("Save() did not write the file of the day: %v", err)
+	}
+
+	loaded, err := store.Load(day.Add(15 * time.Hour))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Entries.Equal(saved.Entries) || loaded.Target != saved.Target || !maps.Equal(loaded.Metadata, saved.Metadata) {
+		t.Errorf("Load() = %v, want %v", loaded, saved)
+	}
+	if other, _ := store.Load(day.AddDate(0, 0, 1)); len(other.Entries) != 0 {
+		t.Errorf("Load() of the next day = %v, want no entries", other.Entries)
+	}
+}
+
+func TestStore_LoadInvalid(t *testing.T) {
+	store := Store{Dir: t.TempDir()}
+	if err := os.WriteFile(filepath.Join(store.Dir, "2025-01-01.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(at(0, 0)); err == nil {
+		t.Error("Load() of a corrupted file succeeded, want an error")
+	}
+}
+
+func TestStore_Days(t *testing.T) {
+	store := Store{Dir: t.TempDir()}
+	if days, err := store.Days(); err != nil || len(days) != 0 {
+		t.Fatalf("Days() of an empty store = %v, %v, want none", days, err)
+	}
+	for _, day := range []time.Time{at(0, 0).AddDate(0, 1, 0), at(0, 0), at(0, 0).AddDate(0, 0, 1)} {
+		if err := store.Save(day, Day{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Other files are ignored
+	if err := os.WriteFile(filepath.Join(store.Dir, "notes.txt"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	days, err := store.Days()
+	if err != nil {
+		t.Fatalf("Days() error = %v", err)
+	}
+	want := []string{"2025-01-01", "2025-01-02", "2025-02-01"}
+	if len(days) != len(want) {
+		t.Fatalf("Days() = %v, want %v", days, want)
+	}
+	for i, day := range days {
+		if got := day.Format("2006-01-02"); got != want[i] {
+			t.Errorf("Days()[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestStore_Location(t *testing.T) {
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+	store := Store{Dir: t.TempDir(), Location: tokyo}
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo)
+	if err := store.Save(day, Day{Entries: Entries{{Time: day.Add(8 * time.Hour)}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load(day)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := loaded.Entries[0].Time; got.Location() != tokyo || got.Hour() != 8 {
+		t.Errorf("Load() = %v, want 08:00 in Tokyo", got)
+	}
+	if days, _ := store.Days(); len(days) != 1 || !days[0].Equal(day) {
+		t.Errorf("Days() = %v, want %v", days, day)
+	}
+}
+
+func TestStore_Undo(t *testing.T) {
+	store := Store{Dir: t.TempDir()}
+	if _, err := store.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo() of an empty store error = %v, want ErrNothingToUndo", err)
+	}
+
+	day := at(0, 0)
+	first := Day{Entries: Entries{{Time: at(8, 0)}}, Target: 8 * time.Hour}
+	second := Day{Entries: Entries{{Time: at(8, 0)}, {Time: at(12, 0)}}, Target: 8 * time.Hour}
+	for _, d := range []Day{first, second, second} {
+		if err := store.Save(day, d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Save(day.AddDate(0, 0, 1), first); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving the same day twice is a single change
+	wants := []struct {
+		date    string
+		entries int
+	}{{"2025-01-02", 0}, {"2025-01-01", 1}, {"2025-01-01", 0}}
+	for i, want := range wants {
+		change, err := store.Undo()
+		if err != nil {
+			t.Fatalf("Undo() #%d error = %v", i+1, err)
+		}
+		if got := change.Day.Format("2006-01-02"); got != want.date {
+			t.Errorf("Undo() #%d changed %s, want %s", i+1, got, want.date)
+		}
+		if loaded, _ := store.Load(change.Day); len(loaded.Entries) != want.entries {
+			t.Errorf("Undo() #%d left %v, want %d entries", i+1, loaded.Entries, want.entries)
+		}
+	}
+	if _, err := os.Stat(store.path(day)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Undo() of the first save left the file of the day: %v", err)
+	}
+	if _, err := store.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() once all is undone error = %v, want ErrNothingToUndo", err)
+	}
+}
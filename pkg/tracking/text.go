@@ -0,0 +1,130 @@
+package tracking
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// Text writes the entries one per line, in the format read by ParseText:
+// "08:00 [acme] $ standup". A ] or \ within a project, and a note which would be
+// read as a project or $, are escaped with \.
+func (entries Entries) Text() string {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(timeutils.FormatTime(e.Time))
+		if e.Project != "" {
+			b.WriteString(" [" + projectEscaper.Replace(e.Project) + "]")
+		}
+		if e.Billable {
+			b.WriteString(" $")
+		}
+		if e.Note != "" {
+			b.WriteString(" " + escapeNote(e.Note))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// projectEscaper escapes the characters ending a project or escaping the next one.
+var projectEscaper = strings.NewReplacer(`\`, `\\`, "]", `\]`)
+
+// escapeNote prefixes a note with \ when it would be read as the project or the $ of
+// billable spans, or when it starts with \ itself.
+func escapeNote(note string) string {
+	if strings.HasPrefix(note, "[") || strings.HasPrefix(note, `\`) || note == "$" || strings.HasPrefix(note, "$ ") {
+		return `\` + note
+	}
+	return note
+}
+
+// cutProject cuts s, following the [ opening a project, at the ] closing it. Any
+// character following \ is part of the project.
+func cutProject(s string) (project, after string, found bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+			}
+			b.WriteByte(s[i])
+		case ']':
+			return b.String(), s[i+1:], true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+// ParseText parses entries written one per line on day: a time, in any format accepted
+// by timeutils.ParseTime, optionally followed by the project between brackets, $ when
+// billable and a note. Within the project, \ escapes the next character, e.g. a ]. A
+// note starting with \ is read from the next character on, so that it can start
+// with [ or $. Blank lines and lines starting with # are ignored. The entries are
+// sorted and validated, see Validate.
+func ParseText(s string, day time.Time) (Entries, error) {
+	var entries Entries
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value, rest, _ := strings.Cut(line, " ")
+		t, err := timeutils.ParseTimeOn(value, day)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		e := Entry{Time: t}
+		rest = strings.TrimSpace(rest)
+		if project, ok := strings.CutPrefix(rest, "["); ok {
+			name, after, closed := cutProject(project)
+			if !closed {
+				return nil, fmt.Errorf("line %d: missing ] after the project", n)
+			}
+			e.Project, rest = strings.TrimSpace(name), strings.TrimSpace(after)
+		}
+		if after, ok := strings.CutPrefix(rest, "$"); ok && (after == "" || after[0] == ' ') {
+			e.Billable, rest = true, strings.TrimSpace(after)
+		}
+		e.Note = strings.TrimPrefix(rest, `\`)
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	entries = entries.sorted()
+	if err := entries.Validate(day); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Validate checks that the entries are those of a day: in chronological order and all
+// on day. A span cannot start and end at the same time, while a span may start when
+// the previous one ends, e.g. when switching projects.
+func (entries Entries) Validate(day time.Time) error {
+	var errs []error
+	for i, e := range entries {
+		if !timeutils.SameDay(e.Time, day) {
+			errs = append(errs, fmt.Errorf("%s is not on %s", e.Time.Format("2006-01-02 15:04"), day.Format("2006-01-02")))
+		}
+		if i == 0 {
+			continue
+		}
+		switch prev := entries[i-1].Time; {
+		case e.Time.Before(prev):
+			errs = append(errs, fmt.Errorf("%s is before %s", timeutils.FormatTime(e.Time), timeutils.FormatTime(prev)))
+		case e.Time.Equal(prev) && i%2 == 1:
+			errs = append(errs, fmt.Errorf("the span starting at %s ends at the same time", timeutils.FormatTime(e.Time)))
+		}
+	}
+	return errors.Join(errs...)
+}
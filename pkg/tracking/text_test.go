@@ -0,0 +1,128 @@
+package tracking
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntries_Text(t *testing.T) {
+	entries := Entries{
+		{Time: at(8, 0), Project: "acme", Billable: true, Note: "standup"},
+		{Time: at(12, 0), Note: "lunch"},
+		{Time: at(12, 45), Billable: true},
+		{Time: at(17, 30)},
+	}
+	want := "08:00 [acme] $ standup\n12:00 lunch\n12:45 $\n17:30\n"
+	if got := entries.Text(); got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	parsed, err := ParseText(entries.Text(), at(0, 0))
+	if err != nil {
+		t.Fatalf("ParseText(Text()) error = %v", err)
+	}
+	if !parsed.Equal(entries) || parsed[0].Billable != entries[0].Billable || parsed[2].Billable != entries[2].Billable {
+		t.Errorf("ParseText(Text()) = %v, want %v", parsed, entries)
+	}
+}
+
+func TestEntries_TextRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		want  string
+	}{
+		{"note like a billable flag", Entry{Time: at(8, 0), Note: "$ 50 expense"}, `08:00 \$ 50 expense`},
+		{"note reading $", Entry{Time: at(8, 0), Note: "$"}, `08:00 \$`},
+		{"note like a project", Entry{Time: at(8, 0), Note: "[wip] draft"}, `08:00 \[wip] draft`},
+		{"note starting with a backslash", Entry{Time: at(8, 0), Note: `\o/`}, `08:00 \\o/`},
+		{"billable note like a billable flag", Entry{Time: at(8, 0), Billable: true, Note: "$ 50"}, `08:00 $ \$ 50`},
+		{"project with brackets", Entry{Time: at(8, 0), Project: "a[1]", Note: "x"}, `08:00 [a[1\]] x`},
+		{"project with a backslash", Entry{Time: at(8, 0), Project: `a\`, Billable: true}, `08:00 [a\\] $`},
+		{"dollar within the note", Entry{Time: at(8, 0), Note: "$5 coffee"}, "08:00 $5 coffee"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := Entries{tt.entry}
+			text := entries.Text()
+			if text != tt.want+"\n" {
+				t.Errorf("Text() = %q, want %q", text, tt.want+"\n")
+			}
+			parsed, err := ParseText(text, at(0, 0))
+			if err != nil {
+				t.Fatalf("ParseText(%q) error = %v", text, err)
+			}
+			if len(parsed) != 1 || parsed[0] != tt.entry {
+				t.Errorf("ParseText(%q) = %+v, want %+v", text, parsed, entries)
+			}
+		})
+	}
+}
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"empty", "# nothing\n\n", "", ""},
+		{"sorted", "1200\n# comment\n8:00 [acme] standup\n", "08:00 [acme] standup\n12:00\n", ""},
+		{"dollar in the note", "8:00 $5 coffee", "08:00 $5 coffee\n", ""},
+		{"project with spaces", "8:00 [big client] $", "08:00 [big client] $\n", ""},
+		{"invalid time", "8:00\nlunch", "", "line 2"},
+		{"unclosed project", "8:00 [acme", "", "line 1: missing ]"},
+		{"empty span", "8:00\n12:00\n800", "", "the span starting at 08:00 ends at the same time"},
+		{"switch", "8:00 [acme]\n10:00\n10:00 [other]\n12:00", "08:00 [acme]\n10:00\n10:00 [other]\n12:00\n", ""},
+		{"escaped bracket in the project", `8:00 [a\]b] note`, `08:00 [a\]b] note` + "\n", ""},
+		{"escaped note", `8:00 \[draft] $ 5`, `08:00 \[draft] $ 5` + "\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseText(tt.input, at(0, 0))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseText(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseText(%q) error = %v", tt.input, err)
+			}
+			if got.Text() != tt.want {
+				t.Errorf("ParseText(%q) = %q, want %q", tt.input, got.Text(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEntries_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries Entries
+		wantErr string
+	}{
+		{"valid", Entries{{Time: at(8, 0)}, {Time: at(12, 0)}}, ""},
+		{"none", nil, ""},
+		{"unsorted", Entries{{Time: at(12, 0)}, {Time: at(8, 0)}}, "08:00 is before 12:00"},
+		{"empty span", Entries{{Time: at(8, 0)}, {Time: at(8, 0)}}, "the span starting at 08:00 ends at the same time"},
+		{"adjacent spans", Entries{{Time: at(8, 0)}, {Time: at(10, 0)}, {Time: at(10, 0)}, {Time: at(12, 0)}}, ""},
+		{"other day", Entries{{Time: at(8, 0).AddDate(0, 0, 1)}}, "2025-01-02 08:00 is not on 2025-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entries.Validate(at(0, 0))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+// Package widget exposes the live status of a running timely instance over a
+// local socket so that desktop widgets (GNOME Shell extensions, KDE plasmoids,
+// panel scripts...) can react to changes without polling.
+//
+// # Protocol
+//
+// The endpoint is a Unix domain socket (see SocketPath). The server speaks
+// newline-delimited JSON and never expects anything from the client:
+//
+//   - as soon as a client connects, the current Status is written as a single line
+//   - every time the status changes, a new Status line is written (change signal)
+//
+// Every line is a complete, self-describing JSON object following the Status
+// schema below, so a client can simply read lines and re-render on each one.
+//
+// Example:
+//
+//	$ nc -U $XDG_RUNTIME_DIR/timely.sock
+//	{"version":1,"clocked_in":true,"total_seconds":14400,...}
+//
+// # HTTP
+//
+// The same Status can be served over HTTP (see Server.Handler) for web
+// dashboards and widgets unable to open Unix sockets:
+//
+//   - GET /status returns the current Status
+//   - GET /events is a server-sent events stream emitting a "status" event,
+//     whose data is a Status, on connection and on every change
+package widget
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SchemaVersion is incremented whenever a breaking change is made to Status.
+// Adding new fields is not considered a breaking change.
+const SchemaVersion = 1
+
+// writeTimeout bounds the time spent writing to a single slow client, which is
+// disconnected once it is exceeded.
+const writeTimeout = time.Second
+
+// ErrInUse is returned by Listen when another running instance already serves
+// the socket.
+var ErrInUse = errors.New("widget socket already in use by another instance")
+
+// Status is the documented payload sent to widgets.
+//
+// Durations are expressed in whole seconds, instants in RFC 3339 format. Instants
+// which are not known yet (e.g. the planned exit before any entry has been
+// recorded) are omitted.
+type Status struct {
+	// Version is the schema version (SchemaVersion).
+	Version int `json:"version"`
+	// ClockedIn reports whether the last recorded entry opens a span.
+	ClockedIn bool `json:"clocked_in"`
+	// TotalSeconds is the time worked in closed spans.
+	TotalSeconds int64 `json:"total_seconds"`
+	// ProvisionalSeconds is the time worked including the currently open span.
+	ProvisionalSeconds int64 `json:"provisional_seconds"`
+	// TargetSeconds is the daily target.
+	TargetSeconds int64 `json:"target_seconds"`
+	// OvertimeSeconds is TotalSeconds - TargetSeconds, negative while the target is not reached.
+	OvertimeSeconds int64 `json:"overtime_seconds"`
+	// Percentage is the completion ratio of the target, between 0 and 1.
+	Percentage float64 `json:"percentage"`
+	// Start is the detected system startup time.
+	Start *time.Time `json:"start,omitempty"`
+	// StartSource names the provider which detected the startup time (e.g. "who -b").
+	StartSource string `json:"start_source,omitempty"`
+	// PlannedExit is the time at which the target will be reached.
+	PlannedExit *time.Time `json:"planned_exit,omitempty"`
+	// PowerSource is "AC" or "battery" when known.
+	PowerSource string `json:"power_source,omitempty"`
+	// Entries lists the recorded clock in/out times in chronological order.
+	Entries []time.Time `json:"entries"`
+}
+
+// SocketPath returns the default location of the widget socket: timely.sock
+// inside $XDG_RUNTIME_DIR when available, a per-user file in the temporary
+// directory otherwise.
+func SocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "timely.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("timely-%d.sock", os.Getuid()))
+}
+
+// Emoji summarizes the status as a single glyph for prompts and bars:
+// 🔴 when working overtime, 🟢 while clocked in and 🟡 otherwise (on a break or
+// not started yet, with time left to work).
+func (s Status) Emoji() string {
+	switch {
+	case s.ProvisionalSeconds > s.TargetSeconds:
+		return "🔴"
+	case s.ClockedIn:
+		return "🟢"
+	default:
+		return "🟡"
+	}
+}
+
+// Fetch connects to the socket at path and returns the current status of the
+// running instance.
+func Fetch(path string) (Status, error) {
+	var s Status
+	conn, err := net.DialTimeout("unix", path, writeTimeout)
+	if err != nil {
+		return s, err
+	}
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(writeTimeout))
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return s, fmt.Errorf("no status received: %w", err)
+	}
+	err = json.Unmarshal(line, &s)
+	return s, err
+}
+
+// Server broadcasts Status updates to every connected widget.
+// A nil *Server is valid and silently discards updates.
+//
+// Each client is written to by a goroutine of its own, so that publishing never
+// waits for a slow widget: a client which did not read the previous status yet
+// only gets the latest one.
+type Server struct {
+	listener net.Listener
+	path     string
+	done     chan struct{} // closed by Close
+	closed   sync.Once
+
+	mu          sync.Mutex
+	current     []byte
+	clients     map[net.Conn]struct{}
+	subscribers map[chan []byte]struct{}
+}
+
+// Listen creates the socket at path and starts accepting widget connections.
+// A stale socket left behind by a crashed instance is replaced, whereas a socket
+// still served by a running instance results in ErrInUse.
+func Listen(path string) (*Server, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, ErrInUse
+	}
+	_ = os.Remove(path)
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		listener:    l,
+		path:        path,
+		done:        make(chan struct{}),
+		clients:     make(map[net.Conn]struct{}),
+		subscribers: make(map[chan []byte]struct{}),
+	}
+	go s.accept()
+	return s, nil
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.clients[conn] = struct{}{}
+		s.mu.Unlock()
+		go s.serve(conn)
+	}
+}
+
+// serve writes the current status, then every change, to a client until a write
+// fails or the server is closed.
+func (s *Server) serve(conn net.Conn) {
+	ch := s.subscribe()
+	defer func() {
+		s.unsubscribe(ch)
+		s.mu.Lock()
+		delete(s.clients, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+	for {
+		select {
+		case <-s.done:
+			return
+		case line := <-ch:
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := conn.Write(line); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Publish records the given status and notifies every connected client if it
+// differs from the previously published one. It never waits for the clients.
+func (s *Server) Publish(status Status) {
+	if s == nil {
+		return
+	}
+	status.Version = SchemaVersion
+
+	line, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if string(line) == string(s.current) {
+		return
+	}
+	s.current = line
+	for ch := range s.subscribers {
+		notify(ch, line)
+	}
+}
+
+// notify delivers line to a subscriber without blocking: a subscriber which did
+// not consume the previous line yet only gets the latest one.
+func notify(ch chan []byte, line []byte) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- line
+}
+
+// subscribe registers a channel receiving the current status, if any, then every change.
+func (s *Server) subscribe() chan []byte {
+	ch := make(chan []byte, 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != nil {
+		ch <- s.current
+	}
+	s.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (s *Server) unsubscribe(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, ch)
+}
+
+// Handler returns an http.Handler serving the status on /status and streaming
+// changes as server-sent events on /events.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", s.serveStatus)
+	mux.HandleFunc("GET /events", s.serveEvents)
+	return mux
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	line := s.current
+	s.mu.Unlock()
+
+	if line == nil {
+		http.Error(w, "status not available yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(line)
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case line := <-ch:
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n", line); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// Close stops accepting connections, disconnects every client and removes the socket.
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.closed.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	for conn := range s.clients {
+		conn.Close()
+	}
+	s.clients = map[net.Conn]struct{}{}
+	s.mu.Unlock()
+
+	_ = os.Remove(s.path)
+	return err
+}
@@ -0,0 +1,236 @@
+package widget
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dial(t *testing.T, path string) *bufio.Reader {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	return bufio.NewReader(conn)
+}
+
+func readStatus(t *testing.T, r *bufio.Reader) Status {
+	t.Helper()
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var s Status
+	if err := json.Unmarshal(line, &s); err != nil {
+		t.Fatalf("unmarshal %q: %v", line, err)
+	}
+	return s
+}
+
+func TestServer_SendsCurrentStatusThenChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timely.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+
+	s.Publish(Status{TotalSeconds: 60})
+	r := dial(t, path)
+
+	got := readStatus(t, r)
+	if got.TotalSeconds != 60 || got.Version != SchemaVersion {
+		t.Fatalf("initial status = %+v", got)
+	}
+
+	// Publishing an identical status must not emit a change signal
+	s.Publish(Status{TotalSeconds: 60})
+	s.Publish(Status{TotalSeconds: 120})
+
+	got = readStatus(t, r)
+	if got.TotalSeconds != 120 {
+		t.Fatalf("changed status = %+v, want total 120", got)
+	}
+}
+
+func TestServer_PublishDoesNotWaitForStuckClients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timely.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+
+	// A client which never reads fills the socket buffers with large statuses
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	entries := make([]time.Time, 20000)
+
+	start := time.Now()
+	for i := range 10 {
+		s.Publish(Status{TotalSeconds: int64(i), Entries: entries})
+	}
+	if elapsed := time.Since(start); elapsed > writeTimeout/2 {
+		t.Errorf("Publish took %s with a stuck client, want it not to wait", elapsed)
+	}
+
+	// Other clients still get the latest status
+	if got := readStatus(t, dial(t, path)); got.TotalSeconds != 9 {
+		t.Errorf("status = %d, want the latest one", got.TotalSeconds)
+	}
+}
+
+func TestListen_InUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timely.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := Listen(path); err != ErrInUse {
+		t.Fatalf("second Listen error = %v, want ErrInUse", err)
+	}
+}
+
+func TestServer_NilIsNoop(t *testing.T) {
+	var s *Server
+	s.Publish(Status{})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close on nil server: %v", err)
+	}
+}
+
+func TestHandler_Status(t *testing.T) {
+	s, err := Listen(filepath.Join(t.TempDir(), "timely.sock"))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("status before publish = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	s.Publish(Status{TotalSeconds: 60})
+	resp, err = http.Get(srv.URL + "/status")
+	if err != nil {
+		t.Fatalf("GET /status: %v", err)
+	}
+	defer resp.Body.Close()
+	var got Status
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.TotalSeconds != 60 {
+		t.Fatalf("status = %+v, want total 60", got)
+	}
+}
+
+func TestHandler_Events(t *testing.T) {
+	s, err := Listen(filepath.Join(t.TempDir(), "timely.sock"))
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	s.Publish(Status{TotalSeconds: 60})
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	readEvent := func() Status {
+		t.Helper()
+		var s Status
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				if err := json.Unmarshal([]byte(data), &s); err != nil {
+					t.Fatalf("unmarshal %q: %v", data, err)
+				}
+			}
+			if line == "\n" {
+				return s
+			}
+		}
+	}
+
+	if got := readEvent(); got.TotalSeconds != 60 {
+		t.Fatalf("first event = %+v, want total 60", got)
+	}
+	s.Publish(Status{TotalSeconds: 120})
+	if got := readEvent(); got.TotalSeconds != 120 {
+		t.Fatalf("second event = %+v, want total 120", got)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timely.sock")
+	if _, err := Fetch(path); err == nil {
+		t.Fatal("expected an error when no instance is running")
+	}
+
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	s.Publish(Status{TotalSeconds: 60})
+
+	got, err := Fetch(path)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if got.TotalSeconds != 60 {
+		t.Fatalf("Fetch() = %+v, want total 60", got)
+	}
+}
+
+func TestStatus_Emoji(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		want   string
+	}{
+		{"clocked in", Status{ClockedIn: true, ProvisionalSeconds: 60, TargetSeconds: 120}, "🟢"},
+		{"on a break", Status{ProvisionalSeconds: 60, TargetSeconds: 120}, "🟡"},
+		{"overtime", Status{ClockedIn: true, ProvisionalSeconds: 180, TargetSeconds: 120}, "🔴"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Emoji(); got != tt.want {
+				t.Errorf("Emoji() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// plainInput is a line read from the input in plain mode.
+type plainInput string
+
+// plainModel runs the tracker without its full screen user interface, for screen
+// readers and dumb terminals: changes are printed as plain lines and the input is
+// read line by line, see readPlainInput.
+type plainModel struct {
+	model
+	out io.Writer
+}
+
+// readPlainInput sends the lines read by scanner to p, and quits at the end of the input.
+func readPlainInput(p *tea.Program, scanner *bufio.Scanner) {
+	for scanner.Scan() {
+		p.Send(plainInput(strings.TrimSpace(scanner.Text())))
+	}
+	p.Quit()
+}
+
+func (p plainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if line, ok := msg.(plainInput); ok {
+		// An empty line reads the status out
+		if line == "" {
+			fmt.Fprintln(p.out, p.plainStatus(p.totalProvisionnal))
+			return p, nil
+		}
+		msg = p.plainKey(string(line))
+		if msg == nil {
+			p.textInput.SetValue(string(line))
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+	}
+
+	updated, cmd := p.model.Update(msg)
+	m, ok := updated.(model)
+	if !ok {
+		return updated, cmd
+	}
+	for _, line := range plainChanges(p.model, m) {
+		fmt.Fprintln(p.out, line)
+	}
+	p.model = m
+	return p, cmd
+}
+
+// View is empty, plain mode runs without renderer.
+func (p plainModel) View() string {
+	return ""
+}
+
+// plainKey returns the key press a line stands for, or nil when it is an input:
+// a single character bound to an action, an answer to a question or esc.
+func (p plainModel) plainKey(line string) tea.Msg {
+	if line == "esc" {
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	runes := []rune(line)
+	if len(runes) != 1 {
+		return nil
+	}
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}
+	if len(p.prompts) > 0 {
+		return msg
+	}
+	if p.composing() {
+		return nil
+	}
+	for _, bindings := range p.keys.FullHelp() {
+		if key.Matches(msg, bindings...) {
+			return msg
+		}
+	}
+	return nil
+}
+
+// plainStatus describes the day on a single line, total being the time worked.
+func (m model) plainStatus(total time.Duration) string {
+	parts := []string{tr("clocked out")}
+	if len(m.entries)%2 == 1 {
+		parts[0] = tr("clocked in")
+	}
+	parts = append(parts, trf("worked %s of %s", timeutils.FormatDuration(total), timeutils.FormatDuration(m.target)))
+	if m.planned != "" {
+		parts = append(parts, tr("exit")+" "+m.planned)
+	}
+	if m.project != "" {
+		parts = append(parts, tr("project")+" "+m.project)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// plainChanges returns the lines describing what changed from before to after.
+// The status only follows the recorded entries, the time worked while clocked in
+// is read out on demand, so that a screen reader is not interrupted every minute.
+func plainChanges(before, after model) []string {
+	var lines []string
+	if after.settingTarget && !before.settingTarget {
+		lines = append(lines, tr("What is your target for today?"))
+	}
+	if after.plainStatus(after.total) != before.plainStatus(before.total) {
+		lines = append(lines, after.plainStatus(after.totalProvisionnal))
+	}
+	if after.targetReached() && !before.targetReached() {
+		lines = append(lines, tr("Daily target reached"))
+	}
+	if after.editing && (!before.editing || after.editIndex != before.editIndex) {
+		lines = append(lines, trf("editing %s, enter the new value or esc", after.textInput.Value()))
+	} else if after.selected != before.selected && after.selected < len(after.entries) {
+		e := after.entries[after.selected]
+		lines = append(lines, strings.TrimSpace(tr("selected")+" "+entryTime(e.Time)+" "+e.Note))
+	}
+	if len(after.prompts) > 0 && (len(before.prompts) == 0 || after.prompts[0].question != before.prompts[0].question) {
+		lines = append(lines, after.prompts[0].question+" (y/n)")
+	}
+	if after.notice != "" && after.notice != before.notice {
+		lines = append(lines, after.notice)
+	}
+	now := after.clock()
+	if after.breakDue(now) && !before.breakDue(now) {
+		_, worked := after.continuousWork(now)
+		lines = append(lines, trf("%s without a break, time for a pause?", timeutils.FormatDuration(worked)))
+	}
+	return lines
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// defaultPomodoro is the classic cycle: 25 minutes of work followed by a 5 minutes break.
+const defaultPomodoro = "25m/5m"
+
+// pomodorosKey is the key of the metadata of a day counting its completed pomodoros.
+const pomodorosKey = "pomodoros"
+
+// pomodoroTick refreshes the pomodoro timer while it runs. run is the run of the
+// timer which started the ticks: the ones of a stopped run are dropped, rather than
+// ticking on alongside those of the next one.
+type pomodoroTick struct {
+	run int
+	at  time.Time
+}
+
+// pomodoro is a work/break cycle timer running alongside the tracking.
+type pomodoro struct {
+	work  time.Duration
+	rest  time.Duration
+	start time.Time
+	// phase is the index of the current phase, work and breaks alternate
+	phase int
+	// run counts the starts of the timer
+	run int
+}
+
+// parsePomodoro parses a cycle written as work/break durations, e.g. "50m/10m".
+func parsePomodoro(s string) (pomodoro, error) {
+	w, r, ok := strings.Cut(s, "/")
+	if !ok {
+		return pomodoro{}, fmt.Errorf("expected work/break durations, got %q", s)
+	}
+	work, err := time.ParseDuration(w)
+	if err != nil {
+		return pomodoro{}, err
+	}
+	rest, err := time.ParseDuration(r)
+	if err != nil {
+		return pomodoro{}, err
+	}
+	if work <= 0 || rest <= 0 {
+		return pomodoro{}, fmt.Errorf("durations must be positive, got %q", s)
+	}
+	return pomodoro{work: work, rest: rest}, nil
+}
+
+// Running reports whether the timer was started.
+func (p pomodoro) Running() bool {
+	return !p.start.IsZero()
+}
+
+// at returns the phase at now, whether it is a work phase and the time left in it.
+func (p pomodoro) at(now time.Time) (phase int, working bool, remaining time.Duration) {
+	elapsed := now.Sub(p.start)
+	cycle := p.work + p.rest
+	within := elapsed % cycle
+	phase = int(elapsed/cycle) * 2
+	if within < p.work {
+		return phase, true, p.work - within
+	}
+	return phase + 1, false, cycle - within
+}
+
+// Completed returns the number of work phases completed.
+func (p pomodoro) Completed(now time.Time) int {
+	phase, _, _ := p.at(now)
+	return (phase + 1) / 2
+}
+
+func tickPomodoro(run int) tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return pomodoroTick{run, t} })
+}
+
+// TogglePomodoro starts the pomodoro timer, or stops it when running.
+func (m model) TogglePomodoro() (model, tea.Cmd) {
+	if m.pomodoro.Running() {
+		m.pomodoro.start = time.Time{}
+		return m, nil
+	}
+	m.pomodoro.start = m.clock()
+	m.pomodoro.phase = 0
+	m.pomodoro.run++
+	return m, tickPomodoro(m.pomodoro.run)
+}
+
+// updatePomodoro notifies the end of each phase and keeps the timer ticking.
+func (m model) updatePomodoro(tick pomodoroTick) (model, tea.Cmd) {
+	if !m.pomodoro.Running() || tick.run != m.pomodoro.run {
+		return m, nil
+	}
+	now := tick.at
+	phase, working, _ := m.pomodoro.at(now)
+	if phase == m.pomodoro.phase {
+		return m, tickPomodoro(m.pomodoro.run)
+	}
+	// Several phases may have ended at once, e.g. while the machine slept
+	if done := (phase+1)/2 - (m.pomodoro.phase+1)/2; done > 0 {
+		m = m.countPomodoros(done)
+	}
+	m.pomodoro.phase = phase
+	if working {
+		return m, tea.Batch(tickPomodoro(m.pomodoro.run), bell, notify("Pomodoro", tr("Break over, back to work")))
+	}
+	return m, tea.Batch(tickPomodoro(m.pomodoro.run), bell,
+		notify("Pomodoro", trf("Pomodoro %d done, take a %s break", m.pomodoro.Completed(now), timeutils.FormatDuration(m.pomodoro.rest))))
+}
+
+// countPomodoros adds completed pomodoros to the metadata of the day, kept for the
+// stats view.
+func (m model) countPomodoros(done int) model {
+	if m.store == nil {
+		return m
+	}
+	current, err := m.store.Load(m.day)
+	if err != nil {
+		m.notice, m.noticeErr = "cannot save the pomodoros: "+err.Error(), true
+		return m
+	}
+	count, _ := strconv.Atoi(current.Metadata[pomodorosKey])
+	current.Metadata = withMetadata(current.Metadata, map[string]string{pomodorosKey: strconv.Itoa(count + done)})
+	if err := m.store.Save(m.day, current); err != nil {
+		m.notice, m.noticeErr = "cannot save the pomodoros: "+err.Error(), true
+		return m
+	}
+	m.stored.Metadata = current.Metadata
+	return m
+}
+
+// pomodoroView renders the count of completed pomodoros and the time left in the current phase.
+func (m model) pomodoroView() string {
+	now := m.clock()
+	_, working, remaining := m.pomodoro.at(now)
+	phase := tr("break")
+	if working {
+		phase = tr("work")
+	}
+	return m.accentStyle().Render(fmt.Sprintf("%d", m.pomodoro.Completed(now))) +
+		helperStyle.Render(" • "+phase+" ") +
+		m.accentStyle().Render(fmt.Sprintf("%02d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestParsePomodoro(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    pomodoro
+		wantErr bool
+	}{
+		{"default", defaultPomodoro, pomodoro{work: 25 * time.Minute, rest: 5 * time.Minute}, false},
+		{"hours", "1h30m/15m", pomodoro{work: 90 * time.Minute, rest: 15 * time.Minute}, false},
+		{"missing break", "25m", pomodoro{}, true},
+		{"invalid work", "25/5m", pomodoro{}, true},
+		{"invalid break", "25m/five", pomodoro{}, true},
+		{"zero break", "25m/0s", pomodoro{}, true},
+		{"negative work", "-25m/5m", pomodoro{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePomodoro(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePomodoro(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parsePomodoro(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPomodoro_At(t *testing.T) {
+	start := time.Date(2025, time.March, 14, 9, 0, 0, 0, time.Local)
+	p := pomodoro{work: 25 * time.Minute, rest: 5 * time.Minute, start: start}
+	tests := []struct {
+		name          string
+		elapsed       time.Duration
+		wantPhase     int
+		wantWorking   bool
+		wantRemaining time.Duration
+		wantCompleted int
+	}{
+		{"start", 0, 0, true, 25 * time.Minute, 0},
+		{"first work", 10 * time.Minute, 0, true, 15 * time.Minute, 0},
+		{"first break", 25 * time.Minute, 1, false, 5 * time.Minute, 1},
+		{"end of the first break", 29*time.Minute + 59*time.Second, 1, false, time.Second, 1},
+		{"second work", 30 * time.Minute, 2, true, 25 * time.Minute, 1},
+		{"third break", 85 * time.Minute, 5, false, 5 * time.Minute, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, working, remaining := p.at(start.Add(tt.elapsed))
+			if phase != tt.wantPhase || working != tt.wantWorking || remaining != tt.wantRemaining {
+				t.Errorf("at(+%s) = %d, %t, %s, want %d, %t, %s", tt.elapsed, phase, working, remaining,
+					tt.wantPhase, tt.wantWorking, tt.wantRemaining)
+			}
+			if got := p.Completed(start.Add(tt.elapsed)); got != tt.wantCompleted {
+				t.Errorf("Completed(+%s) = %d, want %d", tt.elapsed, got, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestModel_TogglePomodoro(t *testing.T) {
+	m := initialModel(8*time.Hour, 0, 0, nil)
+	m.pomodoro = pomodoro{work: 25 * time.Minute, rest: 5 * time.Minute}
+
+	// Stopped and started again within a tick, the timer ticks once
+	m, _ = m.TogglePomodoro()
+	stale := pomodoroTick{m.pomodoro.run, time.Now()}
+	m, _ = m.TogglePomodoro()
+	m, _ = m.TogglePomodoro()
+	if _, cmd := m.updatePomodoro(stale); cmd != nil {
+		t.Error("a tick of the stopped run goes on ticking")
+	}
+	if _, cmd := m.updatePomodoro(pomodoroTick{m.pomodoro.run, time.Now()}); cmd == nil {
+		t.Error("a tick of the current run stops ticking")
+	}
+}
+
+func TestModel_CountPomodoros(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Now()
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, day, tracking.Day{})
+	m.pomodoro = pomodoro{work: 25 * time.Minute, rest: 5 * time.Minute}
+	m, _ = m.TogglePomodoro()
+	start := m.pomodoro.start
+
+	// Two pomodoros ended while no tick came, e.g. while the machine slept
+	m, _ = m.updatePomodoro(pomodoroTick{m.pomodoro.run, start.Add(56 * time.Minute)})
+	// The break is over, no pomodoro was completed
+	m, _ = m.updatePomodoro(pomodoroTick{m.pomodoro.run, start.Add(60 * time.Minute)})
+	stored, err := store.Load(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stored.Metadata[pomodorosKey]; got != "2" || m.stored.Metadata[pomodorosKey] != "2" {
+		t.Errorf("stored %q pomodoros, want 2", got)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// parseProjects parses a comma separated list of project names.
+func parseProjects(s string) []string {
+	var projects []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" && !slices.Contains(projects, p) {
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+// SwitchProject books the time on the next project of the list from now on. The open
+// span is split at now, so that the time worked until then stays on the previous project.
+func (m model) SwitchProject(now time.Time) model {
+	if len(m.projects) == 0 {
+		return m
+	}
+	previous := m.project
+	m.project = m.projects[(slices.Index(m.projects, previous)+1)%len(m.projects)]
+	if len(m.entries)%2 == 0 {
+		return m
+	}
+
+	// A span opened this very minute is simply moved to the new project
+	if last := m.entries.Last(); !now.After(last.Time) {
+		last.Project = m.project
+		last.Billable = m.billableByDefault(m.project)
+		return m.SetEntries(m.entries.Replace(len(m.entries)-1, last))
+	}
+	return m.AppendEntries(tracking.Entries{{Time: now, Project: previous}, {Time: now, Project: m.project}})
+}
+
+// projectsView renders the time worked on each project, the active one highlighted.
+func (m model) projectsView() string {
+	if len(m.projects) == 0 {
+		return ""
+	}
+	totals := m.entries.ProjectTotals(m.now())
+	var parts []string
+	for _, p := range m.projects {
+		style := helperStyle
+		if p == m.project {
+			style = m.accentStyle()
+		}
+		parts = append(parts, style.Render(p+" "+timeutils.FormatDuration(totals[p])))
+	}
+	if other := totals[""]; other > 0 {
+		parts = append(parts, helperStyle.Render(tr("other")+" "+timeutils.FormatDuration(other)))
+	}
+	return strings.Join(parts, helperStyle.Render(" • ")) + "\n"
+}
+
+// billableByDefault reports whether new spans of project are billable, "*" in the
+// billable projects makes every span billable.
+func (m model) billableByDefault(project string) bool {
+	return slices.Contains(m.billable, "*") || (project != "" && slices.Contains(m.billable, project))
+}
+
+// billing reports whether billable time is tracked, the split is then displayed.
+func (m model) billing() bool {
+	return len(m.billable) > 0 || slices.ContainsFunc(m.entries, func(e tracking.Entry) bool { return e.Billable })
+}
+
+// billableView renders the billable and non-billable totals.
+func (m model) billableView() string {
+	billable, nonBillable := m.entries.BillableTotals(m.now())
+	return m.accentStyle().Render(timeutils.FormatDuration(billable)) +
+		helperStyle.Render(" / "+tr("non-billable")+" "+timeutils.FormatDuration(nonBillable))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var promptStyle = lipgloss.NewStyle().Bold(true)
+
+// prompt is a yes/no question displayed above the input.
+// While a prompt is pending, keys are used to answer it.
+type prompt struct {
+	question string
+	// accept is applied to the model when the user answers yes
+	accept func(m model) model
+}
+
+// Ask queues a yes/no question, questions are asked one at a time in order.
+func (m model) Ask(question string, accept func(m model) model) model {
+	m.prompts = append(m.prompts, prompt{question: question, accept: accept})
+	return m
+}
+
+// answerPrompt handles a key press while a prompt is pending.
+func (m model) answerPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.prompts[0]
+	switch msg.String() {
+	case "y", "Y":
+		m.prompts = m.prompts[1:]
+		m = p.accept(m)
+		if m.quitting {
+			return m, tea.Quit
+		}
+		return m, nil
+	case "n", "N", "esc":
+		m.prompts = m.prompts[1:]
+		return m, nil
+	case "ctrl+c":
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// promptView renders the pending question, if any.
+func (m model) promptView() string {
+	if len(m.prompts) == 0 {
+		return ""
+	}
+	return promptStyle.Render(m.prompts[0].question+" (y/n)") + "\n"
+}
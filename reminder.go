@@ -0,0 +1,61 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// breakReminder suggests a pause after working for too long without a break.
+type breakReminder struct {
+	After  time.Duration // continuous work triggering the reminder, 0 disables it
+	Notify bool          // also display a desktop notification
+	// Start of the span the reminder was dismissed, or notified, for: it only
+	// comes back after the next break
+	dismissed time.Time
+	notified  time.Time
+}
+
+// continuousWork returns the start of the open span and how long it has been
+// running at now, a zero start means clocked out.
+func (m model) continuousWork(now time.Time) (time.Time, time.Duration) {
+	if len(m.durations)%2 == 0 {
+		return time.Time{}, 0
+	}
+	start := m.durations.Last()
+	return start, now.Sub(start)
+}
+
+// breakDue reports whether a pause should be suggested at now.
+func (m model) breakDue(now time.Time) bool {
+	start, worked := m.continuousWork(now)
+	return m.reminder.After > 0 && !start.IsZero() && worked >= m.reminder.After && !start.Equal(m.reminder.dismissed)
+}
+
+// DismissReminder hides the reminder until the next break.
+func (m model) DismissReminder() model {
+	m.reminder.dismissed, _ = m.continuousWork(m.clock())
+	return m
+}
+
+// remindBreak notifies once per span that a pause is due, when enabled.
+func (m model) remindBreak(now time.Time) (model, tea.Cmd) {
+	start, worked := m.continuousWork(now)
+	if !m.reminder.Notify || !m.breakDue(now) || start.Equal(m.reminder.notified) {
+		return m, nil
+	}
+	m.reminder.notified = start
+	return m, notify(tr("Time for a break"), trf("You worked %s without a break", timeutils.FormatDuration(worked)))
+}
+
+// reminderView renders the reminder while a pause is due.
+func (m model) reminderView() string {
+	now := m.clock()
+	if !m.breakDue(now) {
+		return ""
+	}
+	_, worked := m.continuousWork(now)
+	return warningStyle.Render("☕ "+trf("%s without a break, time for a pause?", timeutils.FormatDuration(worked))) +
+		helperStyle.Render(" "+trf("%s to dismiss", m.keys.Cancel.Help().Key)) + "\n"
+}
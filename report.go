@@ -0,0 +1,300 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// reportFormats lists the formats of the report command.
+var reportFormats = []string{"text", "csv", "json"}
+
+// reportLine holds the figures of a day, or of the whole period for the total.
+type reportLine struct {
+	Date     string        `json:"date,omitempty"`
+	Worked   time.Duration `json:"-"`
+	Target   time.Duration `json:"-"`
+	Breaks   time.Duration `json:"-"`
+	Overtime time.Duration `json:"-"`
+	// Billable and NonBillable split the time worked by the billable flag of the spans
+	Billable    time.Duration `json:"-"`
+	NonBillable time.Duration `json:"-"`
+	// NoTarget is set for a day whose target is unknown, neither stored nor given by
+	// the options while the day is not off
+	NoTarget bool `json:"-"`
+	// Arrival is the first entry of the day, BreakCount the number of breaks between
+	// its spans and Pomodoros the number of pomodoros completed, see the stats view
+	Arrival    time.Time `json:"-"`
+	BreakCount int       `json:"-"`
+	Pomodoros  int       `json:"-"`
+}
+
+// MarshalJSON writes the durations in whole seconds, as the status does.
+func (l reportLine) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Date               string `json:"date,omitempty"`
+		WorkedSeconds      int64  `json:"worked_seconds"`
+		TargetSeconds      int64  `json:"target_seconds"`
+		OvertimeSeconds    int64  `json:"overtime_seconds"`
+		BreakSeconds       int64  `json:"break_seconds"`
+		BillableSeconds    int64  `json:"billable_seconds"`
+		NonBillableSeconds int64  `json:"non_billable_seconds"`
+	}{l.Date, int64(l.Worked.Seconds()), int64(l.Target.Seconds()), int64(l.Overtime.Seconds()), int64(l.Breaks.Seconds()),
+		int64(l.Billable.Seconds()), int64(l.NonBillable.Seconds())})
+}
+
+// report holds the figures of the days of a period and their total.
+type report struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Days []reportLine `json:"days"`
+	// Weeks holds the total of each ISO week, when the period spans several
+	Weeks []reportLine `json:"weeks,omitempty"`
+	Total reportLine   `json:"total"`
+}
+
+// runReport implements the report command and returns the process exit code.
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	format := fs.String("format", "text", "output format: "+strings.Join(reportFormats, ", "))
+	date := fs.String("date", "today", "day reported, or within the week or month reported: today, yesterday or YYYY-MM-DD")
+	from := fs.String("from", "", "first day reported, instead of a week or a month: today, yesterday or YYYY-MM-DD")
+	to := fs.String("to", "today", "last day reported with --from")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely report [flags] [day | week | month]")
+		fmt.Fprintln(fs.Output(), "Prints the time worked, the target, the overtime, the breaks and the billable time of each day of the period")
+		fmt.Fprintln(fs.Output(), "and their total.")
+		fmt.Fprintln(fs.Output(), "The period is the current week by default, --date moves it and --from reports an arbitrary range.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	period := fs.Arg(0)
+	// Flags are also accepted after the period
+	if fs.NArg() > 0 {
+		fs.Parse(fs.Args()[1:])
+	}
+
+	now := homeNow()
+	start, end, err := reportPeriod(period, *date, *from, *to, now)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	r, err := buildReport(start, end, now)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot build the report:", err)
+		return 1
+	}
+
+	switch *format {
+	case "text":
+		err = r.writeText(os.Stdout)
+	case "csv":
+		err = r.writeCSV(os.Stdout)
+	case "json":
+		err = json.NewEncoder(os.Stdout).Encode(r)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q, expected one of %s\n", *format, strings.Join(reportFormats, ", "))
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// reportPeriod returns the first day of the period reported and midnight after its
+// last one: date itself, its week or its month, or the days from from to to.
+func reportPeriod(period, date, from, to string, now time.Time) (time.Time, time.Time, error) {
+	if from != "" {
+		if period != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("--from reports its own range, %s cannot be given as well", period)
+		}
+		start, err := timeutils.ParseDate(from, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		last, err := timeutils.ParseDate(to, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if last.Before(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s", to, from)
+		}
+		return start, last.AddDate(0, 0, 1), nil
+	}
+
+	day, err := timeutils.ParseDate(date, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	switch period {
+	case "day":
+		return day, day.AddDate(0, 0, 1), nil
+	case "", "week":
+		week := timeutils.WeekOf(day)
+		return week.Start(day.Location()), week.End(day.Location()), nil
+	case "month":
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, 0), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown period %q, expected day, week or month", period)
+}
+
+// buildReport computes the figures of the days from start until end. Days to come
+// are left out, and so are days which were not tracked. Today counts the open span
+// until now.
+func buildReport(start, end, now time.Time) (report, error) {
+	r := report{From: start.Format("2006-01-02"), To: end.AddDate(0, 0, -1).Format("2006-01-02"), Days: []reportLine{}}
+	err := eachReportDay(start, end, now, func(line reportLine) {
+		r.Days = append(r.Days, line)
+		r.Total.add(line)
+	})
+	r.Weeks = weekTotals(r.Days, start, end)
+	return r, err
+}
+
+// add adds the figures of other to the line.
+func (l *reportLine) add(other reportLine) {
+	l.Worked += other.Worked
+	l.Target += other.Target
+	l.Breaks += other.Breaks
+	l.Overtime += other.Overtime
+	l.Billable += other.Billable
+	l.NonBillable += other.NonBillable
+}
+
+// weekTotals returns the total of each ISO week of the days from start until end,
+// labelled "2026-W01", none when the period is within a single week. The first week
+// of a year may begin in December: 29 December 2025 falls in week 1 of 2026.
+func weekTotals(days []reportLine, start, end time.Time) []reportLine {
+	loc := start.Location()
+	if timeutils.WeekOf(start) == timeutils.WeekOf(end.AddDate(0, 0, -1)) {
+		return nil
+	}
+	var weeks []reportLine
+	for week := timeutils.WeekOf(start); week.Start(loc).Before(end); week = week.Next() {
+		total, found := reportLine{Date: week.String()}, false
+		for _, day := range days {
+			if date, err := time.ParseInLocation("2006-01-02", day.Date, loc); err == nil && timeutils.WeekOf(date) == week {
+				total.add(day)
+				found = true
+			}
+		}
+		if found {
+			weeks = append(weeks, total)
+		}
+	}
+	return weeks
+}
+
+// eachReportDay calls fn with the figures of each day tracked from start until end,
+// up to now.
+func eachReportDay(start, end, now time.Time, fn func(reportLine)) error {
+	fullDay, err := time.ParseDuration(flag.Lookup("full-day").Value.String())
+	if err != nil {
+		return err
+	}
+	week, err := parseWorkWeek(flag.Lookup("work-week").Value.String(), fullDay)
+	if err != nil {
+		return err
+	}
+	store, err := dayStore()
+	if err != nil {
+		return err
+	}
+
+	for day := start; day.Before(end) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		stored, err := store.Load(day)
+		if err != nil {
+			return err
+		}
+		// Days never tracked are left out, e.g. before timely was used
+		if len(stored.Entries) == 0 && stored.Target == 0 {
+			continue
+		}
+		// The target the day was tracked with wins over the configured one, which
+		// cannot be a time to leave at for a past day
+		target := stored.Target
+		if target == 0 {
+			target, _, err = todayTarget(week, flag.Lookup("target").Value.String(), fullDay, day)
+			if err != nil {
+				return err
+			}
+		}
+		_, holiday := holidayOn(day)
+
+		var until time.Time
+		if timeutils.SameDay(day, now) {
+			until = now
+		}
+		times := stored.Entries.Times()
+		line := reportLine{
+			Date:   day.Format("2006-01-02"),
+			Worked: timeutils.SumPairedDurationsWithNow(times, until),
+			Target: target,
+			Breaks: times.BreakDuration(time.Time{}),
+			// Holidays and days off have no target, the time worked is overtime
+			NoTarget: target == 0 && !holiday && !dayOff(week, day),
+		}
+		line.Overtime = line.Worked - line.Target
+		line.Billable, line.NonBillable = stored.Entries.BillableTotals(until)
+		if len(times) > 0 {
+			line.Arrival = times[0]
+		}
+		line.BreakCount = len(times.Breaks())
+		line.Pomodoros, _ = strconv.Atoi(stored.Metadata[pomodorosKey])
+		fn(line)
+	}
+	return nil
+}
+
+// writeText writes the report as an aligned table.
+func (r report) writeText(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tworked\ttarget\tovertime\tbreaks\tbillable\tnon-billable\t")
+	row := func(label string, l reportLine) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", label, timeutils.FormatDuration(l.Worked), timeutils.FormatDuration(l.Target),
+			timeutils.FormatDuration(l.Overtime), timeutils.FormatDuration(l.Breaks), timeutils.FormatDuration(l.Billable),
+			timeutils.FormatDuration(l.NonBillable))
+	}
+	for _, day := range r.Days {
+		date, _ := time.Parse("2006-01-02", day.Date)
+		row(date.Format("Mon 2006-01-02"), day)
+	}
+	for _, week := range r.Weeks {
+		row("week "+week.Date, week)
+	}
+	row("total", r.Total)
+	return w.Flush()
+}
+
+// writeCSV writes the report with a line per day, followed by the total.
+func (r report) writeCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	_ = w.Write([]string{"date", "worked", "target", "overtime", "breaks", "billable", "non_billable"})
+	row := func(label string, l reportLine) {
+		_ = w.Write([]string{label, timeutils.FormatDuration(l.Worked), timeutils.FormatDuration(l.Target),
+			timeutils.FormatDuration(l.Overtime), timeutils.FormatDuration(l.Breaks), timeutils.FormatDuration(l.Billable),
+			timeutils.FormatDuration(l.NonBillable)})
+	}
+	for _, day := range r.Days {
+		row(day.Date, day)
+	}
+	for _, week := range r.Weeks {
+		row(week.Date, week)
+	}
+	row("total", r.Total)
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekTotals(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+	}
+	line := func(date string, worked time.Duration) reportLine { return reportLine{Date: date, Worked: worked} }
+	tests := []struct {
+		name       string
+		days       []reportLine
+		start, end time.Time
+		want       map[string]time.Duration
+	}{
+		{"single week", []reportLine{line("2025-03-10", time.Hour), line("2025-03-11", time.Hour)},
+			day(2025, time.March, 10), day(2025, time.March, 17), nil},
+		{"week 1 beginning in December", []reportLine{line("2025-12-29", time.Hour), line("2025-12-31", time.Hour), line("2026-01-02", time.Hour),
+			line("2026-01-05", 2*time.Hour)},
+			day(2025, time.December, 1), day(2026, time.January, 31), map[string]time.Duration{"2026-W01": 3 * time.Hour, "2026-W02": 2 * time.Hour}},
+		{"week 53 ending in January", []reportLine{line("2020-12-31", time.Hour), line("2021-01-01", time.Hour), line("2021-01-04", time.Hour)},
+			day(2020, time.December, 31), day(2021, time.January, 5), map[string]time.Duration{"2020-W53": 2 * time.Hour, "2021-W01": time.Hour}},
+		{"weeks without days left out", []reportLine{line("2025-03-03", time.Hour), line("2025-03-31", time.Hour)},
+			day(2025, time.March, 1), day(2025, time.April, 1), map[string]time.Duration{"2025-W10": time.Hour, "2025-W14": time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weeks := weekTotals(tt.days, tt.start, tt.end)
+			if len(weeks) != len(tt.want) {
+				t.Fatalf("weekTotals() = %v, want %v", weeks, tt.want)
+			}
+			for _, week := range weeks {
+				if want, ok := tt.want[week.Date]; !ok || week.Worked != want {
+					t.Errorf("week %s worked %s, want %s", week.Date, week.Worked, want)
+				}
+			}
+		})
+	}
+}
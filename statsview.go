@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+const (
+	// statsWeeks is the number of weeks of the stats, the current one included
+	statsWeeks = 4
+	// statsBarWidth is the width of the bars of the stats, a cell per hour of the day
+	statsBarWidth = 24
+)
+
+// statsLoaded carries the figures of the days of the stats, see loadStats.
+type statsLoaded struct {
+	start time.Time // midnight of the first day of the stats
+	days  []reportLine
+	found bool
+}
+
+// loadStats reads the figures of the days tracked in the last statsWeeks weeks
+// before the day of now in the background, the tracker counting today as it goes.
+func loadStats(now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		start := timeutils.WeekOf(now).Start(now.Location()).AddDate(0, 0, -7*(statsWeeks-1))
+		var days []reportLine
+		err := eachReportDay(start, timeutils.StartOfDay(now), now, func(line reportLine) {
+			days = append(days, line)
+		})
+		return statsLoaded{start: start, days: days, found: err == nil}
+	}
+}
+
+// dayStats holds the averages of the days worked.
+type dayStats struct {
+	days      int
+	arrival   time.Duration // time of the day
+	length    time.Duration // from the arrival to the end of the last span
+	breaks    time.Duration
+	breakRate float64 // breaks a day
+	pomodoros float64 // pomodoros a day
+}
+
+// averageDays returns the averages of the days of lines with time worked.
+func averageDays(lines []reportLine) dayStats {
+	var s dayStats
+	var pomodoros, breaks int
+	for _, line := range lines {
+		if line.Worked <= 0 || line.Arrival.IsZero() {
+			continue
+		}
+		s.days++
+		s.arrival += line.Arrival.Sub(timeutils.StartOfDay(line.Arrival))
+		s.length += line.Worked + line.Breaks
+		s.breaks += line.Breaks
+		breaks += line.BreakCount
+		pomodoros += line.Pomodoros
+	}
+	if s.days == 0 {
+		return s
+	}
+	n := time.Duration(s.days)
+	s.arrival, s.length, s.breaks = s.arrival/n, s.length/n, s.breaks/n
+	s.breakRate = float64(breaks) / float64(s.days)
+	s.pomodoros = float64(pomodoros) / float64(s.days)
+	return s
+}
+
+// statsBar renders a bar of filled cells out of statsBarWidth.
+func (m model) statsBar(filled int) string {
+	filled = max(min(filled, statsBarWidth), 0)
+	return m.accentStyle().Render(strings.Repeat("█", filled)) + helperStyle.Render(strings.Repeat("░", statsBarWidth-filled))
+}
+
+// statsView renders the habits of the last weeks: the average arrival and length of
+// the days, the breaks, the pomodoros, and the overtime of each week.
+func (m model) statsView() string {
+	b := strings.Builder{}
+	b.WriteString(docHeadingStyle.Render(trf("STATS OF THE LAST %d WEEKS", statsWeeks)) + "\n\n")
+	if !m.stats.found {
+		return b.String() + helperStyle.Render(tr("loading…")) + "\n"
+	}
+
+	today := m
+	if m.today != nil {
+		today = *m.today
+	}
+	avg := averageDays(m.stats.days)
+	var rows [][3]string
+	if avg.days > 0 {
+		rows = append(rows,
+			[3]string{tr("average arrival"), timeutils.FormatDuration(avg.arrival), m.statsBar(int(avg.arrival.Round(time.Hour) / time.Hour))},
+			[3]string{tr("average day length"), timeutils.FormatDuration(avg.length), m.statsBar(int(avg.length.Round(time.Hour) / time.Hour))},
+			[3]string{tr("breaks"), trf("%s, %.1f a day", timeutils.FormatDuration(avg.breaks), avg.breakRate),
+				m.statsBar(int(avg.breaks.Round(time.Hour) / time.Hour))})
+	}
+	pomodoros, _ := strconv.Atoi(today.stored.Metadata[pomodorosKey])
+	rows = append(rows, [3]string{tr("pomodoros"), trf("%d today, %.1f a day", pomodoros, avg.pomodoros),
+		m.statsBar(int(avg.pomodoros + 0.5))})
+	// The labels are aligned on the longest, in any language
+	width := 0
+	for _, row := range rows {
+		width = max(width, len([]rune(row[0])))
+	}
+	if avg.days == 0 {
+		b.WriteString(helperStyle.Render(tr("no day tracked before today")) + "\n")
+	}
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("%-*s  %-22s %s\n", width, row[0], row[1], row[2]))
+	}
+	b.WriteString(helperStyle.Render(trf("█ an hour or a pomodoro, over %d days", avg.days)) + "\n\n")
+
+	b.WriteString(docHeadingStyle.Render(tr("OVERTIME BY WEEK")) + "\n")
+	now := m.clock()
+	week := timeutils.WeekOf(m.stats.start)
+	overtimes := make([]time.Duration, statsWeeks)
+	for i := range overtimes {
+		for _, line := range m.stats.days {
+			date, _ := time.ParseInLocation("2006-01-02", line.Date, now.Location())
+			if timeutils.WeekOf(date) == week {
+				overtimes[i] += line.Overtime
+			}
+		}
+		// Today counts once worked, its target is still ahead otherwise
+		if week == timeutils.WeekOf(now) && today.totalProvisionnal > 0 {
+			line, _ := m.lineOn(nil, now)
+			overtimes[i] += line.Overtime
+		}
+		week = week.Next()
+	}
+	var most time.Duration
+	for _, overtime := range overtimes {
+		most = max(most, overtime, -overtime)
+	}
+	week = timeutils.WeekOf(m.stats.start)
+	for _, overtime := range overtimes {
+		style, sign := reachedStyle, "+"
+		if overtime < 0 {
+			style, sign = unreachedStyle, "-"
+		}
+		filled := 0
+		if most > 0 {
+			filled = int((max(overtime, -overtime)*statsBarWidth + most/2) / most)
+		}
+		label := fmt.Sprintf("W%02d", week.Number)
+		b.WriteString(fmt.Sprintf("%-*s  %s %s\n", width, label, style.Render(fmt.Sprintf("%-22s", sign+timeutils.FormatDuration(max(overtime, -overtime)))),
+			style.Render(strings.Repeat("█", filled))))
+		week = week.Next()
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestAverageDays(t *testing.T) {
+	at := func(s string) time.Time {
+		t, _ := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+		return t
+	}
+	lines := []reportLine{
+		{Arrival: at("2025-03-10 08:00"), Worked: 8 * time.Hour, Breaks: time.Hour, BreakCount: 2, Pomodoros: 3},
+		{Arrival: at("2025-03-11 09:00"), Worked: 6 * time.Hour},
+		// Days off and days without time worked are left out
+		{Target: 8 * time.Hour},
+		{Arrival: at("2025-03-13 07:00")},
+	}
+	want := dayStats{days: 2, arrival: 8*time.Hour + 30*time.Minute, length: 7*time.Hour + 30*time.Minute,
+		breaks: 30 * time.Minute, breakRate: 1, pomodoros: 1.5}
+	if got := averageDays(lines); got != want {
+		t.Errorf("averageDays() = %+v, want %+v", got, want)
+	}
+	if got := averageDays(nil); got != (dayStats{}) {
+		t.Errorf("averageDays(nil) = %+v, want none", got)
+	}
+}
+
+func TestStatsView(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	setFlags(t, map[string]string{"full-day": "8h", "work-week": "", "target": "8:00"})
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	save := func(day tracking.Day, times ...string) {
+		for _, s := range times {
+			at, _ := time.ParseInLocation("2006-01-02 15:04", s, time.Local)
+			day.Entries = append(day.Entries, tracking.Entry{Time: at})
+		}
+		if err := store.Save(day.Entries[0].Time, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save(tracking.Day{Metadata: map[string]string{pomodorosKey: "3"}},
+		"2025-02-18 08:00", "2025-02-18 12:00", "2025-02-18 13:00", "2025-02-18 17:00")
+	save(tracking.Day{}, "2025-03-04 09:00", "2025-03-04 16:00")
+	save(tracking.Day{}, "2025-03-14 07:00", "2025-03-14 09:00") // today, left to the tracker
+	now := time.Date(2025, time.March, 14, 16, 0, 0, 0, time.Local)
+
+	m := initialModel(8*time.Hour, 0, 0, nil)
+	m.screen = statsScreen
+	updated, _ := m.Update(loadStats(now)())
+	view := updated.(model).View()
+	for _, want := range []string{"average arrival     08:30", "average day length  08:00", "00:30, 0.5 a day",
+		"0 today, 1.5 a day", "over 2 days", "W08", "+00:00", "W10", "-01:00", "W11"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("stats lack %q:\n%s", want, view)
+		}
+	}
+}
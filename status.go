@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/widget"
+)
+
+// statusFormats lists the formats of the status command.
+var statusFormats = []string{"plain", "json", "emoji", "waybar"}
+
+// Exit codes of status --quiet when the target is not reached, errors exit with 1.
+const (
+	exitClockedIn  = 3
+	exitClockedOut = 4
+)
+
+// runStatus implements the status command and returns the process exit code.
+func runStatus(args []string) int {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	format := fs.String("format", "plain", "output format: "+strings.Join(statusFormats, ", "))
+	tmpl := fs.String("template", "", "Go template replacing the plain line or the waybar text, e.g. '{{.Total}}/{{.Target}}'")
+	quiet := fs.Bool("quiet", false, "print nothing and exit with 0 when the target is reached, "+
+		strconv.Itoa(exitClockedIn)+" when clocked in and "+strconv.Itoa(exitClockedOut)+" when clocked out otherwise")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely status [flags]")
+		fmt.Fprintln(fs.Output(), "Prints the status of the running tracker, or the one of the stored entries of the day when none is running.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *quiet {
+		status, err := currentStatus()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot compute the status:", err)
+			return 1
+		}
+		return statusCode(status)
+	}
+	return printStatus(*format, *tmpl)
+}
+
+// runWatch implements the watch command, printing the status until interrupted.
+func runWatch(args []string) int {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	format := fs.String("format", "plain", "output format: "+strings.Join(statusFormats, ", "))
+	tmpl := fs.String("template", "", "Go template replacing the plain line or the waybar text, e.g. '{{.Total}}/{{.Target}}'")
+	interval := fs.Duration("interval", 5*time.Second, "time between two lines")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely watch [flags]")
+		fmt.Fprintln(fs.Output(), "Prints the status as timely status does, then again on a new line after every interval, until interrupted.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	f, err := statusFormatter(*format, *tmpl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	if *interval < time.Second {
+		fmt.Fprintln(os.Stderr, "The interval must be at least 1s")
+		return 2
+	}
+
+	for {
+		// A failure is reported and the next attempt may succeed, e.g. once the
+		// tracker has written the entries
+		writeStatus(f)
+		time.Sleep(*interval)
+	}
+}
+
+// currentStatus returns the status of the running tracker or, when none is running,
+// the one computed from the stored entries of the day.
+func currentStatus() (widget.Status, error) {
+	status, err := widget.Fetch(widget.SocketPath())
+	if err != nil {
+		return storedStatus(homeNow())
+	}
+	return status, nil
+}
+
+// statusCode returns the exit code of status --quiet: 0 once the target is reached,
+// otherwise whether the day is clocked in or out.
+func statusCode(s widget.Status) int {
+	switch {
+	case s.TargetSeconds > 0 && s.ProvisionalSeconds >= s.TargetSeconds:
+		return 0
+	case s.ClockedIn:
+		return exitClockedIn
+	default:
+		return exitClockedOut
+	}
+}
+
+// printStatus prints the status of the day in the given format, or with the given
+// template, and returns the process exit code.
+func printStatus(format, tmpl string) int {
+	f, err := statusFormatter(format, tmpl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return writeStatus(f)
+}
+
+// writeStatus prints the status of the day formatted by f and returns the process
+// exit code.
+func writeStatus(f func(widget.Status) (string, error)) int {
+	status, err := currentStatus()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot compute the status:", err)
+		return 1
+	}
+	line, err := f(status)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(line)
+	return 0
+}
+
+// statusFormatter returns the function formatting a status in format. A template
+// replaces the plain line, or the text of the waybar format.
+func statusFormatter(format, tmpl string) (func(widget.Status) (string, error), error) {
+	text := func(s widget.Status) (string, error) { return statusLine(s), nil }
+	if tmpl != "" {
+		if format != "plain" && format != "waybar" {
+			return nil, fmt.Errorf("--template applies to the plain and waybar formats, not to %s", format)
+		}
+		t, err := template.New("status").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		text = func(s widget.Status) (string, error) {
+			var b strings.Builder
+			err := t.Execute(&b, newStatusFields(s))
+			return b.String(), err
+		}
+	}
+
+	switch format {
+	case "plain":
+		return text, nil
+	case "json":
+		return func(s widget.Status) (string, error) {
+			b, err := json.Marshal(s)
+			return string(b), err
+		}, nil
+	case "emoji":
+		return func(s widget.Status) (string, error) { return s.Emoji(), nil }, nil
+	case "waybar":
+		return func(s widget.Status) (string, error) {
+			w := waybarStatus{Text: waybarText(s), Tooltip: statusLine(s), Class: newStatusFields(s).Class}
+			if tmpl != "" {
+				var err error
+				if w.Text, err = text(s); err != nil {
+					return "", err
+				}
+			}
+			if s.TargetSeconds > 0 {
+				w.Percentage = int(min(100, s.ProvisionalSeconds*100/s.TargetSeconds))
+			}
+			b, err := json.Marshal(w)
+			return string(b), err
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(statusFormats, ", "))
+}
+
+// statusFields are the fields available to the templates of the status.
+type statusFields struct {
+	ClockedIn bool
+	State     string // in or out
+	Class     string // in, out or done once the target is reached
+	Emoji     string
+	Total     string // time worked, including the open span
+	Target    string
+	Overtime  string
+	Exit      string // planned exit, empty when unknown
+}
+
+// newStatusFields formats the figures of a status for the templates.
+func newStatusFields(s widget.Status) statusFields {
+	worked := time.Duration(s.ProvisionalSeconds) * time.Second
+	target := time.Duration(s.TargetSeconds) * time.Second
+	f := statusFields{
+		ClockedIn: s.ClockedIn,
+		State:     "out",
+		Emoji:     s.Emoji(),
+		Total:     timeutils.FormatDuration(worked),
+		Target:    timeutils.FormatDuration(target),
+		Overtime:  timeutils.FormatDuration(worked - target),
+	}
+	if s.ClockedIn {
+		f.State = "in"
+	}
+	f.Class = f.State
+	if statusCode(s) == 0 {
+		f.Class = "done"
+	}
+	if s.PlannedExit != nil {
+		f.Exit = timeutils.FormatTime(*s.PlannedExit)
+	}
+	return f
+}
+
+// waybarStatus is the output of custom modules of waybar, which other bars also read.
+type waybarStatus struct {
+	Text       string `json:"text"`
+	Tooltip    string `json:"tooltip"`
+	Class      string `json:"class"`
+	Percentage int    `json:"percentage"`
+}
+
+// waybarText is the default text of the waybar format: "04:12 / 08:00".
+func waybarText(s widget.Status) string {
+	f := newStatusFields(s)
+	if s.TargetSeconds <= 0 {
+		return f.Total
+	}
+	return f.Total + " / " + f.Target
+}
+
+// storedStatus returns the status of the day of now from the stored entries, with the
+// target it was tracked with or, when unknown, the one set by the flags.
+func storedStatus(now time.Time) (widget.Status, error) {
+	fullDay, err := time.ParseDuration(flag.Lookup("full-day").Value.String())
+	if err != nil {
+		return widget.Status{}, err
+	}
+	week, err := parseWorkWeek(flag.Lookup("work-week").Value.String(), fullDay)
+	if err != nil {
+		return widget.Status{}, err
+	}
+	target, until, err := todayTarget(week, flag.Lookup("target").Value.String(), fullDay, now)
+	if err != nil {
+		return widget.Status{}, err
+	}
+	store, err := dayStore()
+	if err != nil {
+		return widget.Status{}, err
+	}
+	day, err := store.Load(now)
+	if err != nil {
+		return widget.Status{}, err
+	}
+	// The target the day was tracked with wins over the configured one
+	if day.Target > 0 {
+		target, until = day.Target, time.Time{}
+	}
+
+	m := initialModel(target, 0, 0, nil).SetEntries(day.Entries)
+	if !until.IsZero() {
+		m = m.SetTarget(0, timeutils.OnDay(until, now))
+	}
+	status := m.Status()
+	status.Version = widget.SchemaVersion
+	return status, nil
+}
+
+// statusLine describes a status on a single line: "in 04:12 / 08:00, overtime -03:48, exit 17:29".
+// The time worked includes the open span, only it is given when the target is unknown.
+func statusLine(s widget.Status) string {
+	state := "out"
+	if s.ClockedIn {
+		state = "in"
+	}
+	worked := time.Duration(s.ProvisionalSeconds) * time.Second
+	target := time.Duration(s.TargetSeconds) * time.Second
+	if target <= 0 {
+		// Without target, there is neither overtime nor exit
+		return state + " " + timeutils.FormatDuration(worked)
+	}
+	parts := []string{
+		state + " " + timeutils.FormatDuration(worked) + " / " + timeutils.FormatDuration(target),
+		"overtime " + timeutils.FormatDuration(worked-target),
+	}
+	if s.PlannedExit != nil {
+		parts = append(parts, "exit "+timeutils.FormatTime(*s.PlannedExit))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+	"github.com/fredjeck/timely/pkg/widget"
+)
+
+// setFlags gives the global flags read by the commands the values of the test,
+// defining those the test binary lacks. They are reset once the test is over.
+func setFlags(t *testing.T, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		f := flag.Lookup(name)
+		if f == nil {
+			flag.String(name, "", "")
+			f = flag.Lookup(name)
+		}
+		previous := f.Value.String()
+		if err := flag.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { flag.Set(name, previous) })
+	}
+}
+
+// statusAt returns a status of seconds worked, including the open span, with an
+// optional planned exit at hour:min.
+func statusAt(in bool, worked, target time.Duration, exit string) widget.Status {
+	s := widget.Status{ClockedIn: in, ProvisionalSeconds: int64(worked.Seconds()), TargetSeconds: int64(target.Seconds())}
+	if exit != "" {
+		t, _ := time.ParseInLocation("15:04", exit, time.Local)
+		s.PlannedExit = &t
+	}
+	return s
+}
+
+func TestStatusLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		status widget.Status
+		want   string
+	}{
+		{"clocked in", statusAt(true, 4*time.Hour+12*time.Minute, 8*time.Hour, "17:29"), "in 04:12 / 08:00, overtime -03:48, exit 17:29"},
+		{"clocked out past the target", statusAt(false, 9*time.Hour, 8*time.Hour, ""), "out 09:00 / 08:00, overtime 01:00"},
+		{"without target", statusAt(true, 2*time.Hour, 0, "10:00"), "in 02:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusLine(tt.status); got != tt.want {
+				t.Errorf("statusLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status widget.Status
+		want   int
+	}{
+		{"target reached", statusAt(true, 8*time.Hour, 8*time.Hour, ""), 0},
+		{"clocked in", statusAt(true, time.Hour, 8*time.Hour, ""), exitClockedIn},
+		{"clocked out", statusAt(false, time.Hour, 8*time.Hour, ""), exitClockedOut},
+		{"without target", statusAt(false, 9*time.Hour, 0, ""), exitClockedOut},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusCode(tt.status); got != tt.want {
+				t.Errorf("statusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStatusFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		status widget.Status
+		want   statusFields
+	}{
+		{"clocked in", statusAt(true, 4*time.Hour, 8*time.Hour, "17:00"),
+			statusFields{ClockedIn: true, State: "in", Class: "in", Emoji: "🟢", Total: "04:00", Target: "08:00", Overtime: "-04:00", Exit: "17:00"}},
+		{"done", statusAt(false, 8*time.Hour+30*time.Minute, 8*time.Hour, ""),
+			statusFields{State: "out", Class: "done", Emoji: "🔴", Total: "08:30", Target: "08:00", Overtime: "00:30"}},
+		{"on a break", statusAt(false, time.Hour, 8*time.Hour, ""),
+			statusFields{State: "out", Class: "out", Emoji: "🟡", Total: "01:00", Target: "08:00", Overtime: "-07:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newStatusFields(tt.status); got != tt.want {
+				t.Errorf("newStatusFields() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaybarText(t *testing.T) {
+	tests := []struct {
+		name   string
+		status widget.Status
+		want   string
+	}{
+		{"with target", statusAt(true, 4*time.Hour+12*time.Minute, 8*time.Hour, ""), "04:12 / 08:00"},
+		{"without target", statusAt(true, 2*time.Hour, 0, ""), "02:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := waybarText(tt.status); got != tt.want {
+				t.Errorf("waybarText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusFormatter(t *testing.T) {
+	status := statusAt(true, 4*time.Hour, 8*time.Hour, "17:00")
+	tests := []struct {
+		name    string
+		format  string
+		tmpl    string
+		want    string
+		wantErr string
+	}{
+		{"plain", "plain", "", "in 04:00 / 08:00, overtime -04:00, exit 17:00", ""},
+		{"plain template", "plain", "{{.State}} {{.Total}}/{{.Target}}", "in 04:00/08:00", ""},
+		{"emoji", "emoji", "", "🟢", ""},
+		{"json", "json", "", `{"version":0,"clocked_in":true,`, ""},
+		{"waybar", "waybar", "", `{"text":"04:00 / 08:00","tooltip":"in 04:00 / 08:00, overtime -04:00, exit 17:00","class":"in","percentage":50}`, ""},
+		{"waybar template", "waybar", "{{.Emoji}} {{.Exit}}", `{"text":"🟢 17:00",`, ""},
+		{"template of the json", "json", "{{.Total}}", "", "--template applies to the plain and waybar formats"},
+		{"invalid template", "plain", "{{.Total", "", "invalid template"},
+		{"unknown format", "xml", "", "", `unknown format "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := statusFormatter(tt.format, tt.tmpl)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("statusFormatter() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("statusFormatter() error = %v", err)
+			}
+			got, err := f(status)
+			if err != nil {
+				t.Fatalf("formatting error = %v", err)
+			}
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("formatted %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoredStatus(t *testing.T) {
+	now := time.Date(2025, time.March, 14, 16, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, time.March, 14, hour, min, 0, 0, time.Local)
+	}
+	spans := tracking.Entries{{Time: at(8, 0)}, {Time: at(12, 0)}, {Time: at(13, 0)}, {Time: at(15, 0)}}
+	tests := []struct {
+		name       string
+		workWeek   string
+		target     string
+		stored     tracking.Day
+		wantTarget time.Duration
+		wantWorked time.Duration
+	}{
+		{"nothing stored", "", "8:00", tracking.Day{}, 8 * time.Hour, 0},
+		{"target of the work week", "fri=6:00", "8:00", tracking.Day{Entries: spans}, 6 * time.Hour, 6 * time.Hour},
+		{"stored target wins", "fri=6:00", "8:00", tracking.Day{Entries: spans, Target: 7 * time.Hour}, 7 * time.Hour, 6 * time.Hour},
+		// The time to leave at gives 8 hours, the break included
+		{"until", "", "until 17:00", tracking.Day{Entries: spans}, 8 * time.Hour, 6 * time.Hour},
+		{"stored target wins over until", "", "until 17:00", tracking.Day{Entries: spans, Target: 5 * time.Hour}, 5 * time.Hour, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(platform.DataDirEnv, t.TempDir())
+			setFlags(t, map[string]string{"full-day": "8h", "work-week": tt.workWeek, "target": tt.target})
+			store, err := dayStore()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Save(now, tt.stored); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := storedStatus(now)
+			if err != nil {
+				t.Fatalf("storedStatus() error = %v", err)
+			}
+			if got.Version != widget.SchemaVersion || got.TargetSeconds != int64(tt.wantTarget.Seconds()) ||
+				got.TotalSeconds != int64(tt.wantWorked.Seconds()) || got.ClockedIn {
+				t.Errorf("storedStatus() = %+v, want target %s and worked %s", got, tt.wantTarget, tt.wantWorked)
+			}
+		})
+	}
+}
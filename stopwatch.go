@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/stopwatch"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// stopwatchKeys are the key bindings of the stopwatch command.
+var stopwatchKeys = struct {
+	Toggle key.Binding
+	Lap    key.Binding
+	Reset  key.Binding
+	Quit   key.Binding
+}{
+	Toggle: key.NewBinding(key.WithKeys(" ", "s"), key.WithHelp("space", "start/stop")),
+	Lap:    key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "lap")),
+	Reset:  key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset")),
+	Quit:   key.NewBinding(key.WithKeys("q", "esc", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// stopwatchModel is an ad-hoc elapsed timer, independent from the tracked entries.
+type stopwatchModel struct {
+	label     string
+	stopwatch stopwatch.Model
+	// laps holds the elapsed time at which each lap was recorded
+	laps     []time.Duration
+	paused   bool
+	quitting bool
+}
+
+func (m stopwatchModel) Init() tea.Cmd {
+	if m.paused {
+		return nil
+	}
+	return m.stopwatch.Init()
+}
+
+func (m stopwatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, stopwatchKeys.Quit):
+			m.quitting = true
+			return m, tea.Quit
+		case key.Matches(msg, stopwatchKeys.Toggle):
+			return m, m.stopwatch.Toggle()
+		case key.Matches(msg, stopwatchKeys.Lap):
+			if m.stopwatch.Running() {
+				m.laps = append(m.laps, m.stopwatch.Elapsed())
+			}
+			return m, nil
+		case key.Matches(msg, stopwatchKeys.Reset):
+			m.laps = nil
+			return m, m.stopwatch.Reset()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.stopwatch, cmd = m.stopwatch.Update(msg)
+	return m, cmd
+}
+
+func (m stopwatchModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	var b strings.Builder
+	if m.label != "" {
+		b.WriteString(helperStyle.Render(m.label) + "\n")
+	}
+	style := reachedStyle
+	if !m.stopwatch.Running() {
+		style = helperStyle
+	}
+	b.WriteString(style.Render(timeutils.FormatDurationSeconds(m.stopwatch.Elapsed())) + "\n\n")
+	b.WriteString(m.lapsView())
+	b.WriteString("\n" + helperStyle.Render("space start/stop • l lap • r reset • q quit"))
+	return b.String()
+}
+
+// lapsView lists each lap with its own duration and the elapsed time when it was recorded.
+func (m stopwatchModel) lapsView() string {
+	var b strings.Builder
+	previous := time.Duration(0)
+	for i, at := range m.laps {
+		fmt.Fprintf(&b, "lap %d  %s  %s\n", i+1,
+			timeutils.FormatDurationSeconds(at-previous), helperStyle.Render(timeutils.FormatDurationSeconds(at)))
+		previous = at
+	}
+	return b.String()
+}
+
+// summary describes the measurement once the stopwatch is closed, one line per lap.
+func (m stopwatchModel) summary() string {
+	var b strings.Builder
+	if m.label != "" {
+		b.WriteString(m.label + " ")
+	}
+	b.WriteString(timeutils.FormatDurationSeconds(m.stopwatch.Elapsed()) + "\n")
+	previous := time.Duration(0)
+	for i, at := range m.laps {
+		fmt.Fprintf(&b, "lap %d %s\n", i+1, timeutils.FormatDurationSeconds(at-previous))
+		previous = at
+	}
+	return b.String()
+}
+
+// runStopwatch implements the stopwatch command and returns the process exit code.
+func runStopwatch(args []string) int {
+	fs := flag.NewFlagSet("stopwatch", flag.ExitOnError)
+	paused := fs.Bool("paused", false, "wait for space before starting")
+	themeName := fs.String("theme", "", "color theme: "+strings.Join(themeNames(), ", ")+", defaults to the one of the tracker")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely stopwatch [flags] [label]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *themeName != "" {
+		t, err := selectTheme(*themeName)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		t.apply()
+	}
+
+	m := stopwatchModel{
+		label:     strings.Join(fs.Args(), " "),
+		stopwatch: stopwatch.NewWithInterval(time.Second),
+		paused:    *paused,
+	}
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error running stopwatch:", err)
+		return 1
+	}
+	fmt.Print(final.(stopwatchModel).summary())
+	return 0
+}
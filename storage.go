@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+const (
+	// daysDir is the name of the directory, within the data directory, keeping the entries of each day.
+	daysDir = "days"
+	// storeCheckInterval is how often the tracker checks whether the entries of the
+	// day were changed by another process, e.g. timely add.
+	storeCheckInterval = 2 * time.Second
+)
+
+// entriesStored carries the stored day after another process changed it.
+type entriesStored tracking.Day
+
+// dayStore returns the store keeping the entries of each day in the data directory.
+func dayStore() (tracking.Store, error) {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return tracking.Store{}, err
+	}
+	return tracking.Store{Dir: filepath.Join(dir, daysDir), Location: homeLocation()}, nil
+}
+
+// homeLocation returns the time zone of --home-tz the days are counted in, the one
+// of the system when it is not set. main checks that the time zone is known.
+func homeLocation() *time.Location {
+	f := flag.Lookup("home-tz")
+	if f == nil || f.Value.String() == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(f.Value.String())
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// homeNow returns the current time in the home time zone, see homeLocation.
+func homeNow() time.Time {
+	return time.Now().In(homeLocation())
+}
+
+// SetStore makes the tracker keep its entries in store, as the ones of day, stored
+// being what the store holds of it.
+func (m model) SetStore(store tracking.Store, day time.Time, stored tracking.Day) model {
+	m.store = &store
+	m.day = day
+	m.stored = stored
+	return m.SetEntries(stored.Entries).Select(len(stored.Entries) - 1)
+}
+
+// save stores the entries and the target, keeping the metadata, a failure is reported as a notice. Another
+// process may have changed the day since the tracker last read it, e.g. timely add
+// run between two checks of the store: its changes are merged rather than overwritten.
+func (m model) save() model {
+	if m.store == nil {
+		return m
+	}
+	current, err := m.store.Load(m.day)
+	if err != nil {
+		m.notice, m.noticeErr = "cannot save the entries: "+err.Error(), true
+		return m
+	}
+	if !current.Entries.Equal(m.stored.Entries) {
+		m = m.SetEntries(tracking.Merge(m.stored.Entries, m.entries, current.Entries))
+	}
+	day := tracking.Day{Entries: m.entries, Target: m.target, Metadata: current.Metadata}
+	if err := m.store.Save(m.day, day); err != nil {
+		m.notice, m.noticeErr = "cannot save the entries: "+err.Error(), true
+		return m
+	}
+	m.stored = day
+	return m
+}
+
+// runAdd implements the add command and returns the process exit code.
+func runAdd(args []string) int {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	date := fs.String("date", "", "day of the times without date of their own: today, yesterday or YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely add [flags] [times]")
+		fmt.Fprintln(fs.Output(), "Records times, as typed in the input of the tracker, in the stored entries of their day.")
+		fmt.Fprintln(fs.Output(), "Without times, the current time is recorded.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	now := homeNow()
+	entries := tracking.Entries{{Time: now.Truncate(time.Minute)}}
+	if input := strings.Join(fs.Args(), " "); input != "" {
+		var err error
+		if entries, err = tracking.ParseEntries(input, now); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if *date != "" {
+		day, err := timeutils.ParseDate(*date, now)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		// Times parsed without date are on today
+		for i, e := range entries {
+			if timeutils.SameDay(e.Time, now) {
+				entries[i].Time = timeutils.OnDay(e.Time, day)
+			}
+		}
+		slices.SortStableFunc(entries, func(a, b tracking.Entry) int { return a.Time.Compare(b.Time) })
+	}
+
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+		return 1
+	}
+	// Entries are sorted, those of a same day follow each other. The days are all
+	// checked before any is stored, e.g. a hook run twice records no empty span.
+	var dates []time.Time
+	var days []tracking.Day
+	for len(entries) > 0 {
+		n := 1
+		for n < len(entries) && timeutils.SameDay(entries[n].Time, entries[0].Time) {
+			n++
+		}
+		t := entries[0].Time
+		day, err := store.Load(t)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+			return 1
+		}
+		for _, e := range entries[:n] {
+			day.Entries = day.Entries.Add(e)
+		}
+		if err := day.Entries.Validate(t); err != nil {
+			fmt.Fprintln(os.Stderr, "Nothing stored, "+t.Format("2006-01-02")+": "+strings.ReplaceAll(err.Error(), "\n", ", "))
+			return 1
+		}
+		dates, days = append(dates, t), append(days, day)
+		entries = entries[n:]
+	}
+	for i, t := range dates {
+		if err := store.Save(t, days[i]); err != nil {
+			fmt.Fprintln(os.Stderr, "Cannot store the entries:", err)
+			return 1
+		}
+		fmt.Println(t.Format("2006-01-02") + " " + daySummary(days[i].Entries))
+	}
+	return 0
+}
+
+// daySummary describes stored entries on a single line: "08:02-12:00, 12:45-…, total 03:58".
+func daySummary(entries tracking.Entries) string {
+	var parts []string
+	for _, span := range pairs(entries) {
+		parts = append(parts, spanText(span))
+	}
+	return strings.Join(append(parts, "total "+timeutils.FormatDuration(timeutils.SumPairedDurationsWithNow(entries.Times(), time.Time{}))), ", ")
+}
+
+// runUndo implements the undo command and returns the process exit code.
+func runUndo(args []string) int {
+	fs := flag.NewFlagSet("undo", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely undo")
+		fmt.Fprintln(fs.Output(), "Reverts the last change of the stored entries, run again to revert the one before.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	store, err := dayStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot undo:", err)
+		return 1
+	}
+	change, err := store.Undo()
+	if errors.Is(err, tracking.ErrNothingToUndo) {
+		fmt.Fprintln(os.Stderr, "Nothing to undo")
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Cannot undo:", err)
+		return 1
+	}
+	day, err := store.Load(change.Day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println("Undid the change made at " + change.At.Format("2006-01-02 15:04"))
+	fmt.Println(change.Day.Format("2006-01-02") + " " + daySummary(day.Entries))
+	return 0
+}
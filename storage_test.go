@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestModel_SaveKeepsStoredChanges(t *testing.T) {
+	day := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+	at := func(hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	store := tracking.Store{Dir: t.TempDir()}
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, day, tracking.Day{Entries: tracking.Entries{{Time: at(8, 0)}}})
+
+	// timely add records a break before the tracker notices it
+	if err := store.Save(day, tracking.Day{Entries: tracking.Entries{{Time: at(8, 0)}, {Time: at(10, 0)}, {Time: at(10, 15)}}}); err != nil {
+		t.Fatal(err)
+	}
+	m = m.SetEntries(m.entries.Add(tracking.Entry{Time: at(12, 0)})).save()
+
+	want := tracking.Entries{{Time: at(8, 0)}, {Time: at(10, 0)}, {Time: at(10, 15)}, {Time: at(12, 0)}}
+	stored, err := store.Load(day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Entries.Equal(want) {
+		t.Errorf("stored %v, want %v", stored.Entries, want)
+	}
+	if !m.entries.Equal(want) {
+		t.Errorf("tracker shows %v, want %v", m.entries, want)
+	}
+
+	// Without changes from elsewhere, the tracker's removals are kept
+	m = m.SetEntries(m.entries.Remove(3)).save()
+	if stored, _ = store.Load(day); !stored.Entries.Equal(want[:3]) {
+		t.Errorf("stored %v after a removal, want %v", stored.Entries, want[:3])
+	}
+}
+
+func TestHomeLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	dir := t.TempDir()
+	t.Setenv(platform.DataDirEnv, dir)
+	setFlags(t, map[string]string{"home-tz": "Asia/Tokyo"})
+
+	if got := homeNow().Location(); got.String() != tokyo.String() {
+		t.Errorf("homeNow() is in %s, want %s", got, tokyo)
+	}
+	// The times given are the ones of the home time zone, whatever the local one
+	if code := runStart([]string{"--date", "2025-03-14", "08:00"}); code != 0 {
+		t.Fatalf("runStart() = %d, want 0", code)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, daysDir, "2025-03-14.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "2025-03-14T08:00:00+09:00") {
+		t.Errorf("stored %s, want 08:00 in Tokyo", b)
+	}
+}
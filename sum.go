@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// runSum implements the sum command and returns the process exit code.
+func runSum(args []string) int {
+	fs := flag.NewFlagSet("sum", flag.ExitOnError)
+	now := fs.Bool("now", false, "close a trailing unpaired time with the current time instead of ignoring it")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: timely sum [flags] < times")
+		fmt.Fprintln(fs.Output(), "Prints the total of the paired times read from stdin, separated by spaces or new lines.")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	times, err := readTimes(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	var at time.Time
+	if *now {
+		at = time.Now()
+	}
+	fmt.Println(timeutils.FormatDuration(timeutils.SumPairedDurationsWithNow(times, at)))
+	return 0
+}
+
+// readTimes reads whitespace separated times, in any format accepted by timeutils.ParseTime.
+func readTimes(r io.Reader) (timeutils.Durations, error) {
+	var times timeutils.Durations
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		t, err := timeutils.ParseTime(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("unknown time %q", scanner.Text())
+		}
+		times = times.Append(t)
+	}
+	return times, scanner.Err()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+func TestReadTimes(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTimes int
+		wantTotal time.Duration
+		wantErr   string
+	}{
+		{"empty", "", 0, 0, ""},
+		{"pairs on lines", "8:00 12:00\n12:45 17:30\n", 4, 8*time.Hour + 45*time.Minute, ""},
+		{"formats and spacing", "  800\t1200 \n\n1245 1730", 4, 8*time.Hour + 45*time.Minute, ""},
+		{"trailing unpaired time", "08:00 12:00 13:00", 3, 4 * time.Hour, ""},
+		{"unknown time", "08:00 noon", 0, 0, `unknown time "noon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			times, err := readTimes(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readTimes() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readTimes() error = %v", err)
+			}
+			if len(times) != tt.wantTimes {
+				t.Errorf("readTimes() read %d times, want %d", len(times), tt.wantTimes)
+			}
+			if total := timeutils.SumPairedDurationsWithNow(times, time.Time{}); total != tt.wantTotal {
+				t.Errorf("total = %s, want %s", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestRunSum(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "times")
+	if err := os.WriteFile(in, []byte("8:00 12:00\n12:45 17:30\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stdin, err := os.Open(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdin.Close()
+	stdout, err := os.Create(filepath.Join(dir, "total"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	defer func(in, out *os.File) { os.Stdin, os.Stdout = in, out }(os.Stdin, os.Stdout)
+	os.Stdin, os.Stdout = stdin, stdout
+
+	if code := runSum(nil); code != 0 {
+		t.Fatalf("runSum() = %d, want 0", code)
+	}
+	if b, _ := os.ReadFile(stdout.Name()); string(b) != "08:45\n" {
+		t.Errorf("runSum() printed %q, want \"08:45\\n\"", b)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+// pairs groups the entries by span, the last one has a single entry while clocked in.
+func pairs(entries tracking.Entries) []tracking.Entries {
+	var spans []tracking.Entries
+	for i := 0; i < len(entries); i += 2 {
+		spans = append(spans, entries[i:min(i+2, len(entries))])
+	}
+	return spans
+}
+
+// spanText formats a span as "08:02-12:00", an open span ending with "…".
+// Notes are appended between parentheses.
+func spanText(span tracking.Entries) string {
+	text := timeutils.FormatTime(span[0].Time) + "-"
+	if len(span) > 1 {
+		text += timeutils.FormatTime(span[1].Time)
+	} else {
+		text += "…"
+	}
+	if span[0].Project != "" {
+		text += " [" + span[0].Project + "]"
+	}
+	var notes []string
+	for _, e := range span {
+		if e.Note != "" {
+			notes = append(notes, e.Note)
+		}
+	}
+	if len(notes) > 0 {
+		text += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return text
+}
+
+// summaryLine describes the day on a single line:
+// "08:02-12:00, 12:45-…, total 06:13, exit 17:29".
+func (m model) summaryLine() string {
+	var parts []string
+	for _, span := range pairs(m.entries) {
+		parts = append(parts, spanText(span))
+	}
+	parts = append(parts, "total "+timeutils.FormatDuration(m.totalProvisionnal))
+	if m.planned != "" {
+		parts = append(parts, "exit "+m.planned)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// summaryLines describes the day with one span per line, followed by the totals.
+func (m model) summaryLines() string {
+	var b strings.Builder
+	for _, span := range pairs(m.entries) {
+		b.WriteString(spanText(span) + "\n")
+	}
+	b.WriteString("total " + timeutils.FormatDuration(m.totalProvisionnal) + " / " + timeutils.FormatDuration(m.target) + "\n")
+	if m.planned != "" {
+		b.WriteString("exit " + m.planned + "\n")
+	}
+	if m.billing() {
+		billable, nonBillable := m.entries.BillableTotals(m.clock())
+		b.WriteString("billable " + timeutils.FormatDuration(billable) + ", non-billable " + timeutils.FormatDuration(nonBillable) + "\n")
+	}
+	return b.String()
+}
+
+// quitSummary describes the day once the tracker is closed: the spans, the total,
+// the overtime and the time spent on breaks. It is empty when nothing was recorded.
+func (m model) quitSummary() string {
+	if len(m.entries) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, span := range pairs(m.entries) {
+		b.WriteString(spanText(span) + "\n")
+	}
+	b.WriteString("total    " + timeutils.FormatDuration(m.totalProvisionnal) + " / " + timeutils.FormatDuration(m.target) + "\n")
+	b.WriteString("overtime " + timeutils.FormatDuration(m.totalProvisionnal-m.target) + "\n")
+	b.WriteString("breaks   " + timeutils.FormatDuration(m.durations.BreakDuration(time.Time{})) + "\n")
+	if m.billing() {
+		billable, _ := m.entries.BillableTotals(m.clock())
+		b.WriteString("billable " + timeutils.FormatDuration(billable) + "\n")
+	}
+	return b.String()
+}
+
+// copied reports the outcome of a copy to the clipboard. err is the failure of the
+// clipboard tool, the text was then sent to the terminal unless sent is false.
+type copied struct {
+	err  error
+	sent bool
+}
+
+// copyToClipboard puts text on the clipboard, falling back to the OSC 52 escape
+// sequence when the clipboard tool fails or is missing (e.g. over SSH). Whether the
+// terminal supports it is unknown, so the failure of the tool is still reported.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		err := platform.CopyToClipboard(text)
+		if err == nil {
+			return copied{}
+		}
+		_, sendErr := terminal.WriteString(platform.ClipboardSequence(text))
+		return copied{err: err, sent: sendErr == nil}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestSpanText(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, time.March, 14, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name string
+		span tracking.Entries
+		want string
+	}{
+		{"closed", tracking.Entries{{Time: at(8, 2)}, {Time: at(12, 0)}}, "08:02-12:00"},
+		{"open", tracking.Entries{{Time: at(12, 45)}}, "12:45-…"},
+		{"project", tracking.Entries{{Time: at(8, 0), Project: "acme"}, {Time: at(9, 0), Project: "acme"}}, "08:00-09:00 [acme]"},
+		{"notes of both ends", tracking.Entries{{Time: at(8, 0), Note: "standup"}, {Time: at(9, 0), Note: "done"}},
+			"08:00-09:00 (standup, done)"},
+		{"project and note", tracking.Entries{{Time: at(13, 0), Project: "web", Note: "review"}}, "13:00-… [web] (review)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spanText(tt.span); got != tt.want {
+				t.Errorf("spanText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_SummaryLine(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2025, time.March, 14, hour, min, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name  string
+		model model
+		want  string
+	}{
+		{"nothing recorded", model{}, "total 00:00"},
+		{"clocked in", model{
+			entries:           tracking.Entries{{Time: at(8, 2)}, {Time: at(12, 0)}, {Time: at(12, 45)}},
+			totalProvisionnal: 6*time.Hour + 13*time.Minute,
+			planned:           "17:29",
+		}, "08:02-12:00, 12:45-…, total 06:13, exit 17:29"},
+		{"without exit", model{
+			entries:           tracking.Entries{{Time: at(8, 0), Project: "acme"}, {Time: at(9, 30)}},
+			totalProvisionnal: 90 * time.Minute,
+		}, "08:00-09:30 [acme], total 01:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.summaryLine(); got != tt.want {
+				t.Errorf("summaryLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
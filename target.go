@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// targetFile is the name of the file, within the data directory, remembering the last target used.
+const targetFile = "target"
+
+// defaultFullDay is the length of a full-time day, percentage targets are shares of it.
+const defaultFullDay = 8 * time.Hour
+
+// lastTarget returns the last target used, as typed in the input, or "" when unknown.
+func lastTarget() string {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return ""
+	}
+	b, err := os.ReadFile(filepath.Join(dir, targetFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// saveTarget remembers target, as typed, as the last one used, this is best effort.
+func saveTarget(target string) {
+	dir, err := platform.DataDir()
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, targetFile), []byte(strings.TrimSpace(target)+"\n"), 0o600)
+}
+
+// parseTarget parses a daily target, in any format accepted by the time input, as a
+// share of fullDay ("80%"), or the time to leave at written as "until 17:00". In the
+// latter case, the returned duration is zero and until is the time to leave at,
+// today in the home time zone.
+func parseTarget(s string, fullDay time.Duration) (target time.Duration, until time.Time, err error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "until"); ok {
+		until, err = timeutils.ParseTimeOn(strings.TrimSpace(rest), homeNow())
+		return 0, until, err
+	}
+	if strings.HasSuffix(s, "%") {
+		share, err := parseShare(s)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		return shareOf(fullDay, share), time.Time{}, nil
+	}
+	t, err := timeutils.ParseTime(s)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, time.Time{}, nil
+}
+
+// parseShare parses a percentage, "80%" gives 0.8.
+func parseShare(s string) (float64, error) {
+	var percent float64
+	if _, err := fmt.Sscanf(s, "%g%%", &percent); err != nil || percent <= 0 || percent > 100 {
+		return 0, fmt.Errorf("%s: not a percentage between 0%% and 100%%", s)
+	}
+	return percent / 100, nil
+}
+
+// shareOf returns share of d, to the minute.
+func shareOf(d time.Duration, share float64) time.Duration {
+	return time.Duration(float64(d) * share).Round(time.Minute)
+}
+
+// weekdays names the days of the week in work weeks, see parseWorkWeek.
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"fri": time.Friday, "sat": time.Saturday, "sun": time.Sunday,
+}
+
+// parseWorkWeek parses the target of each day of the week, in space or comma separated
+// day=target assignments: "mon=100% wed=50% fri=6:00 sat=0". Targets are shares of
+// fullDay or durations in any format accepted by the time input, 0 is a day off.
+func parseWorkWeek(s string, fullDay time.Duration) (map[time.Weekday]time.Duration, error) {
+	week := make(map[time.Weekday]time.Duration)
+	for _, assignment := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		name, value, ok := strings.Cut(assignment, "=")
+		day, known := weekdays[strings.ToLower(name)]
+		if !ok || !known {
+			return nil, fmt.Errorf("expected day=target with days among mon, tue, wed, thu, fri, sat and sun, got %q", assignment)
+		}
+		if strings.HasPrefix(value, "until") {
+			return nil, fmt.Errorf("%s: a work week holds durations or percentages, not times to leave at", assignment)
+		}
+		target, _, err := parseTarget(value, fullDay)
+		if err != nil {
+			return nil, err
+		}
+		week[day] = target
+	}
+	return week, nil
+}
+
+// todayTarget returns the target of the day of now when none is given: the one of the
+// day in week, or the default one written as fallback. Both the target and until are
+// zero when there is none, or when the day is off or a holiday.
+func todayTarget(week map[time.Weekday]time.Duration, fallback string, fullDay time.Duration, now time.Time) (target time.Duration, until time.Time, err error) {
+	if _, ok := holidayOn(now); ok {
+		return 0, time.Time{}, nil
+	}
+	if target, ok := week[now.Weekday()]; ok {
+		return target, time.Time{}, nil
+	}
+	if fallback == "" {
+		return 0, time.Time{}, nil
+	}
+	return parseTarget(fallback, fullDay)
+}
+
+// dayOff reports whether the day of now is off in week.
+func dayOff(week map[time.Weekday]time.Duration, now time.Time) bool {
+	target, ok := week[now.Weekday()]
+	return ok && target == 0
+}
+
+// targetText returns the target as it is typed, see parseTarget.
+func (m model) targetText() string {
+	if !m.until.IsZero() {
+		return "until " + timeutils.FormatTime(m.until)
+	}
+	return timeutils.FormatDuration(m.target)
+}
+
+// AskTarget loads the target into the input so that it can be changed, value
+// pre-fills it.
+func (m model) AskTarget(value string) model {
+	m.settingTarget = true
+	m.textInput.ShowSuggestions = false
+	m.textInput.Prompt = "target> "
+	m.textInput.SetValue(value)
+	m.textInput.CursorEnd()
+	return m
+}
+
+// SetTarget changes the daily target, or the time to leave at when until is set,
+// see parseTarget. Leaving at a given time brings the countdown forward.
+func (m model) SetTarget(target time.Duration, until time.Time) model {
+	m.target, m.until = target, until
+	if !until.IsZero() {
+		m.countdown = true
+	}
+	return m.RecalculateDurations()
+}
+
+// untilTarget returns the target leading to an exit at m.until: the time between
+// the start of the day and the exit, minus the breaks already taken.
+func (m model) untilTarget() time.Duration {
+	start := m.startupTime
+	if len(m.durations) > 0 {
+		start = m.durations[0]
+	}
+	if start.IsZero() {
+		start = m.clock()
+	}
+	return max(m.until.Sub(start)-m.durations.BreakDuration(time.Time{}), 0)
+}
+
+// targetView asks for the target of the day before anything else is displayed.
+func (m model) targetView() string {
+	return docHeadingStyle.Render(tr("What is your target for today?")) + "\n\n" +
+		m.textInput.View() + "\n" +
+		m.noticeView() + "\n" +
+		helperStyle.Render(trf("enter to start • %s to quit", m.keys.Cancel.Help().Key))
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// theme holds the colors of the user interface.
+type theme struct {
+	// accent colors reached figures, the open entry and code in the documentation
+	accent lipgloss.TerminalColor
+	// alert colors the total while the target is not reached
+	alert lipgloss.TerminalColor
+	// warning colors the overtime past the warning threshold
+	warning lipgloss.TerminalColor
+	// muted colors labels and secondary information
+	muted lipgloss.TerminalColor
+	// highlight colors the selection, questions and headings
+	highlight lipgloss.TerminalColor
+	// badge colors the text of the IN/OUT badges
+	badge lipgloss.TerminalColor
+	// progress is the gradient of the progress bar
+	progress [2]string
+	// flash is the color of the progress bar when flashing
+	flash string
+	// breaks is the color of the break progress bar
+	breaks string
+	// header is the default gradient of the header values, see parseHeaderGradient
+	header string
+	// plain disables colors altogether
+	plain bool
+}
+
+// themes are the built-in themes, selected with --theme.
+var themes = map[string]theme{
+	"dark": {
+		accent:    lipgloss.Color("34"),
+		alert:     lipgloss.Color("9"),
+		warning:   lipgloss.Color("214"),
+		muted:     lipgloss.Color("#626262"),
+		highlight: lipgloss.Color("170"),
+		badge:     lipgloss.Color("230"),
+		progress:  [2]string{"#FF7CCB", "#FDFF8C"},
+		flash:     "34",
+		breaks:    "#5fafff",
+		header:    "#5fafff,#ffaf5f,#ff5f5f",
+	},
+	"light": {
+		accent:    lipgloss.Color("28"),
+		alert:     lipgloss.Color("1"),
+		warning:   lipgloss.Color("130"),
+		muted:     lipgloss.Color("244"),
+		highlight: lipgloss.Color("90"),
+		badge:     lipgloss.Color("255"),
+		progress:  [2]string{"#8E24AA", "#F57C00"},
+		flash:     "28",
+		breaks:    "#1f5fbf",
+		header:    "#1f5fbf,#b35900,#c00000",
+	},
+	"mono": {
+		accent:    lipgloss.NoColor{},
+		alert:     lipgloss.NoColor{},
+		warning:   lipgloss.NoColor{},
+		muted:     lipgloss.NoColor{},
+		highlight: lipgloss.NoColor{},
+		badge:     lipgloss.NoColor{},
+		progress:  [2]string{"#FFFFFF", "#FFFFFF"},
+		flash:     "#FFFFFF",
+		breaks:    "#FFFFFF",
+		plain:     true,
+	},
+}
+
+// activeTheme is the theme applied to the styles.
+var activeTheme = themes["dark"]
+
+func init() {
+	activeTheme.apply()
+}
+
+// themeNames lists the built-in themes, sorted.
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectTheme returns the theme named name. Without a name, the mono theme is
+// used when the NO_COLOR environment variable is set (see https://no-color.org)
+// and the dark one otherwise.
+func selectTheme(name string) (theme, error) {
+	if name == "" {
+		name = "dark"
+		if os.Getenv("NO_COLOR") != "" {
+			name = "mono"
+		}
+	}
+	t, ok := themes[name]
+	if !ok {
+		return theme{}, fmt.Errorf("unknown theme %q, available themes: %v", name, themeNames())
+	}
+	return t, nil
+}
+
+// apply colors the styles of the user interface and makes t the active theme.
+func (t theme) apply() {
+	activeTheme = t
+	if t.plain {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	selectedItemStyle = selectedItemStyle.Foreground(t.highlight)
+	unreachedStyle = unreachedStyle.Foreground(t.alert)
+	warningStyle = warningStyle.Foreground(t.warning)
+	errorStyle = errorStyle.Foreground(t.alert)
+	bannerStyle = bannerStyle.Foreground(t.alert).BorderForeground(t.alert)
+	reachedStyle = reachedStyle.Foreground(t.accent)
+	helperStyle = helperStyle.Foreground(t.muted)
+	openItemStyle = openItemStyle.Foreground(t.accent)
+	clockedInStyle = clockedInStyle.Background(t.accent).Foreground(t.badge)
+	clockedOutStyle = clockedOutStyle.Background(t.muted).Foreground(t.badge)
+	promptStyle = promptStyle.Foreground(t.highlight)
+	docHeadingStyle = docHeadingStyle.Foreground(t.highlight)
+	docCodeStyle = docCodeStyle.Foreground(t.accent)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// Glyphs of the timeline, one per time slot.
+const (
+	timelineWorked = "█"
+	timelineBreak  = "░"
+	timelineFuture = "·"
+	timelineNow    = "│"
+)
+
+// timelineView renders the day as a horizontal bar in which worked spans, breaks,
+// the current moment and the remaining time until the planned exit are proportional.
+func (m model) timelineView(width int) string {
+	if len(m.durations) == 0 {
+		return ""
+	}
+	from := m.durations[0].Truncate(time.Hour)
+	now := m.clock()
+	if m.today != nil {
+		// A past day ends with its last entry
+		now = m.durations.Last()
+	}
+	to := now
+	if exit := m.durations.Last().Add(m.target - m.total + m.plannedBreak); m.today == nil && len(m.durations)%2 == 1 && exit.After(to) {
+		to = exit
+	}
+	if last := m.durations.Last(); last.After(to) {
+		to = last
+	}
+
+	labels := timeutils.FormatTime(from) + " " + timeutils.FormatTime(to)
+	width -= len(labels) + 2
+	if width < 10 {
+		return ""
+	}
+	slot := to.Sub(from) / time.Duration(width)
+	if slot <= 0 {
+		return ""
+	}
+
+	// The open span runs until now
+	spans := m.durations.Spans()
+	if len(m.durations)%2 == 1 {
+		spans = append(spans, timeutils.Span{Start: m.durations.Last(), End: now})
+	}
+
+	var bar strings.Builder
+	nowMarked := false
+	for i := 0; i < width; i++ {
+		start := from.Add(time.Duration(i) * slot)
+		end := start.Add(slot)
+		switch {
+		case !nowMarked && m.today == nil && !now.Before(start) && now.Before(end):
+			bar.WriteString(m.accentStyle().Render(timelineNow))
+			nowMarked = true
+		case covers(spans, start, end):
+			bar.WriteString(reachedStyle.Render(timelineWorked))
+		case start.After(now):
+			bar.WriteString(helperStyle.Render(timelineFuture))
+		default:
+			bar.WriteString(helperStyle.Render(timelineBreak))
+		}
+	}
+	return helperStyle.Render(timeutils.FormatTime(from)+" ") + bar.String() + helperStyle.Render(" "+timeutils.FormatTime(to))
+}
+
+// covers reports whether one of the spans covers the middle of the slot [start, end).
+func covers(spans []timeutils.Span, start, end time.Time) bool {
+	middle := start.Add(end.Sub(start) / 2)
+	for _, s := range spans {
+		if !middle.Before(s.Start) && middle.Before(s.End) {
+			return true
+		}
+	}
+	return false
+}
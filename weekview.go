@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/timeutils"
+)
+
+// screen is what the tracker displays, tab goes from one screen to the next.
+type screen int
+
+const (
+	trackerScreen screen = iota
+	weekScreen
+	calendarScreen
+	statsScreen
+	// screenCount is the number of screens, not a screen
+	screenCount
+)
+
+// weekBarWidth is the width of the progress bars of the days of the week.
+const weekBarWidth = 10
+
+// weekLoaded carries the figures of the days of a week, see loadWeek.
+type weekLoaded struct {
+	week  timeutils.Week
+	days  []reportLine
+	found bool
+}
+
+// worked returns the time worked on the days loaded.
+func (w weekLoaded) worked() time.Duration {
+	var worked time.Duration
+	for _, day := range w.days {
+		worked += day.Worked
+	}
+	return worked
+}
+
+// loadWeek reads the figures of the days of the week of day tracked before the day
+// of now in the background, the tracker counting today as it goes. Failures leave
+// the week out, timely report week reports them.
+func loadWeek(day, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		week := timeutils.WeekOf(day)
+		var days []reportLine
+		end := week.End(day.Location())
+		if today := timeutils.StartOfDay(now); today.Before(end) {
+			end = today
+		}
+		err := eachReportDay(week.Start(day.Location()), end, now, func(line reportLine) {
+			days = append(days, line)
+		})
+		return weekLoaded{week: week, days: days, found: err == nil}
+	}
+}
+
+// NextScreen shows the next screen, back to the tracker after the last one. The
+// figures of the screen are loaded anew, past days may have been corrected since.
+func (m model) NextScreen() (model, tea.Cmd) {
+	m.screen = (m.screen + 1) % screenCount
+	switch m.screen {
+	case weekScreen:
+		return m, loadWeek(m.day, m.clock())
+	case calendarScreen:
+		m.calendarDay = timeutils.StartOfDay(m.day)
+		return m, loadMonth(m.calendarDay, m.clock())
+	case statsScreen:
+		return m, loadStats(m.clock())
+	}
+	return m, nil
+}
+
+// updateScreen handles the keys while a screen other than the tracker is shown.
+func (m model) updateScreen(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.ForceQuit):
+		m.quitting = true
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.View):
+		return m.NextScreen()
+	case key.Matches(msg, m.keys.Cancel, m.keys.Quit):
+		m.screen = trackerScreen
+	case m.screen == calendarScreen:
+		return m.updateCalendar(msg)
+	}
+	return m, nil
+}
+
+// screenView renders the screen shown in place of the tracker.
+func (m model) screenView() string {
+	var view string
+	help := []string{m.keys.View.Help().Key + " " + tr("next view"), m.keys.Cancel.Help().Key + " " + tr("back")}
+	switch m.screen {
+	case weekScreen:
+		view = m.weekView()
+	case statsScreen:
+		view = m.statsView()
+	case calendarScreen:
+		view = m.calendarView()
+		help = append([]string{strings.Join([]string{m.keys.Left.Help().Key, m.keys.Right.Help().Key,
+			m.keys.Up.Help().Key, m.keys.Down.Help().Key}, "/") + " " + tr("move"),
+			m.keys.Add.Help().Key + " " + tr("show the day")}, help...)
+	}
+	return view + "\n" + helperStyle.Render(strings.Join(help, " • "))
+}
+
+// weekView renders a row per day of the week of the day shown: the time worked, the
+// target, the difference and a progress bar. Today's figures are the live ones.
+func (m model) weekView() string {
+	week := timeutils.WeekOf(m.day)
+	b := strings.Builder{}
+	b.WriteString(docHeadingStyle.Render(fmt.Sprintf("%s W%02d", tr("WEEK"), week.Number)) + "\n\n")
+	if !m.week.found || m.week.week != week {
+		return b.String() + helperStyle.Render(tr("loading…")) + "\n"
+	}
+
+	var total reportLine
+	for day := week.Start(m.day.Location()); day.Before(week.End(m.day.Location())); day = day.AddDate(0, 0, 1) {
+		label := formatDate(day, "Mon 2 Jan")
+		line, tracked := m.lineOn(m.week.days, day)
+		if !tracked {
+			b.WriteString(helperStyle.Render(fmt.Sprintf("%-11s %5s", label, "—")) + "\n")
+			continue
+		}
+		total.Worked += line.Worked
+		total.Target += line.Target
+		total.Overtime += line.Overtime
+		b.WriteString(m.weekRow(label, line) + "\n")
+	}
+	b.WriteString("\n" + m.weekRow(tr("total"), total) + "\n")
+	return b.String()
+}
+
+// lineOn returns the figures of day among lines, the live ones for today, and
+// whether the day was tracked.
+func (m model) lineOn(lines []reportLine, day time.Time) (reportLine, bool) {
+	if timeutils.SameDay(day, m.clock()) {
+		today := m
+		if m.today != nil {
+			today = *m.today
+		}
+		return reportLine{Worked: today.totalProvisionnal, Target: today.target,
+			Overtime: today.totalProvisionnal - today.target}, true
+	}
+	date := day.Format("2006-01-02")
+	for _, line := range lines {
+		if line.Date == date {
+			return line, true
+		}
+	}
+	return reportLine{}, false
+}
+
+// weekRow renders the figures of a day, or of the week, with a progress bar.
+func (m model) weekRow(label string, line reportLine) string {
+	delta := reachedStyle
+	if line.Overtime < 0 {
+		delta = unreachedStyle
+	}
+	filled := weekBarWidth
+	if line.Target > 0 {
+		filled = min(int(line.Worked*weekBarWidth/line.Target), weekBarWidth)
+	}
+	bar := m.accentStyle().Render(strings.Repeat("█", filled)) + helperStyle.Render(strings.Repeat("░", weekBarWidth-filled))
+	return fmt.Sprintf("%-11s %5s %s %5s  %s  %s", label, timeutils.FormatDuration(line.Worked), helperStyle.Render("/"),
+		timeutils.FormatDuration(line.Target), delta.Render(fmt.Sprintf("%6s", timeutils.FormatDuration(line.Overtime))), bar)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fredjeck/timely/pkg/platform"
+	"github.com/fredjeck/timely/pkg/tracking"
+)
+
+func TestWeekView(t *testing.T) {
+	t.Setenv(platform.DataDirEnv, t.TempDir())
+	setFlags(t, map[string]string{"full-day": "8h", "work-week": "", "target": "8:00"})
+	store, err := dayStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	span := func(day string, hours int) tracking.Day {
+		start, _ := time.ParseInLocation("2006-01-02 15:04", day+" 08:00", time.Local)
+		stored := tracking.Day{Entries: tracking.Entries{{Time: start}, {Time: start.Add(time.Duration(hours) * time.Hour)}}}
+		if err := store.Save(start, stored); err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+	span("2025-03-10", 4)
+	stored := span("2025-03-11", 9)
+	span("2025-03-14", 2)
+	day := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.Local)
+	m := initialModel(8*time.Hour, 0, 0, nil).SetStore(store, day, stored)
+
+	// tab shows the week, loaded in the background
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(model)
+	if m.screen != weekScreen || cmd == nil {
+		t.Fatalf("tab shows screen %d, want the week", m.screen)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(model)
+	view := m.View()
+	for _, want := range []string{"W11", "Mon 10 Mar  04:00", "Tue 11 Mar  09:00", "Fri 14 Mar  02:00", "-04:00", "total       15:00 / 24:00  -09:00"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("week view lacks %q:\n%s", want, view)
+		}
+	}
+	if !strings.Contains(view, "Wed 12 Mar      —") {
+		t.Errorf("week view shows a figure for a day not tracked:\n%s", view)
+	}
+
+	// esc goes back to the tracker
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(model).screen != trackerScreen {
+		t.Errorf("esc leaves screen %d shown, want the tracker", updated.(model).screen)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fredjeck/timely/pkg/platform"
+)
+
+var wizardTitleStyle = lipgloss.NewStyle().Bold(true)
+
+// weekdayNames lists the days of the week in order, see weekdays.
+var weekdayNames = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// wizardStep is a question of the first-run setup, its answer is the value of a setting.
+type wizardStep struct {
+	question string
+	setting  string
+	fallback string // answer used when nothing is typed
+	// parse validates the answer and returns the value of the setting
+	parse func(answer string) (string, error)
+}
+
+// wizardSteps returns the questions of the first-run setup.
+func wizardSteps() []wizardStep {
+	dataDir, _ := platform.DataDir()
+	return []wizardStep{
+		{
+			question: "How long is a full working day?",
+			setting:  "full-day",
+			fallback: "8h",
+			parse:    parsePositiveDuration,
+		},
+		{
+			question: "Which days do you work? (e.g. mon-fri, or mon-thu fri=6:00 for a shorter day)",
+			setting:  "work-week",
+			fallback: "mon-fri",
+			parse:    parseWorkDays,
+		},
+		{
+			question: "How much break do you take during a day? (0 for none)",
+			setting:  "break-budget",
+			fallback: "45m",
+			parse:    parseDuration,
+		},
+		{
+			question: "Suggest a pause after working how long without a break? (0 never does)",
+			setting:  "break-reminder",
+			fallback: "0",
+			parse:    parseDuration,
+		},
+		{
+			question: "Where should timely keep its data?",
+			setting:  "data-dir",
+			fallback: dataDir,
+			parse:    func(s string) (string, error) { return s, nil },
+		},
+	}
+}
+
+// formatDuration formats d as written in the settings, e.g. "8h30m".
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// parseDuration validates a duration answer, e.g. "45m" or "0".
+func parseDuration(s string) (string, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return "", fmt.Errorf("expected a duration such as 45m or 1h30m, got %q", s)
+	}
+	return formatDuration(d), nil
+}
+
+// parsePositiveDuration validates a duration answer which cannot be zero.
+func parsePositiveDuration(s string) (string, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return "", fmt.Errorf("expected a duration such as 8h or 7h30m, got %q", s)
+	}
+	return formatDuration(d), nil
+}
+
+// parseWorkDays turns days ("mon"), ranges of days ("mon-fri") and targets of days
+// ("fri=50%" or "fri=6:00") into a work week, see parseWorkWeek.
+func parseWorkDays(s string) (string, error) {
+	var assignments []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ' ' || r == ',' }) {
+		if strings.Contains(field, "=") {
+			assignments = append(assignments, field)
+			continue
+		}
+		first, last, isRange := strings.Cut(field, "-")
+		if !isRange {
+			last = first
+		}
+		from, to := slices.Index(weekdayNames, first), slices.Index(weekdayNames, last)
+		if from < 0 || to < from {
+			return "", fmt.Errorf("expected days among %s or ranges such as mon-fri, got %q", strings.Join(weekdayNames, ", "), field)
+		}
+		for _, day := range weekdayNames[from : to+1] {
+			assignments = append(assignments, day+"=100%")
+		}
+	}
+	week := strings.Join(assignments, " ")
+	if _, err := parseWorkWeek(week, defaultFullDay); err != nil {
+		return "", err
+	}
+	return week, nil
+}
+
+// wizard is the first-run setup, it asks its steps one after the other.
+type wizard struct {
+	steps    []wizardStep
+	step     int
+	answers  []setting
+	input    textinput.Model
+	err      error
+	skipped  bool // the setup was skipped, the answers are incomplete
+	quitting bool // the user wants to leave timely altogether
+}
+
+func newWizard(steps []wizardStep) wizard {
+	w := wizard{steps: steps, input: textinput.New()}
+	w.input.Focus()
+	w.input.Placeholder = steps[0].fallback
+	return w
+}
+
+func (w wizard) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (w wizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			w.quitting = true
+			return w, tea.Quit
+		case tea.KeyEsc:
+			w.skipped = true
+			return w, tea.Quit
+		case tea.KeyEnter:
+			if w.err = w.answer(w.input.Value()); w.err != nil {
+				return w, nil
+			}
+			w.step++
+			if w.step == len(w.steps) {
+				return w, tea.Quit
+			}
+			w.input.Reset()
+			w.input.Placeholder = w.steps[w.step].fallback
+			return w, nil
+		}
+	}
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return w, cmd
+}
+
+// answer records the answer to the current step, the fallback when empty.
+func (w *wizard) answer(answer string) error {
+	s := w.steps[w.step]
+	if answer = strings.TrimSpace(answer); answer == "" {
+		answer = s.fallback
+	}
+	value, err := s.parse(answer)
+	if err != nil {
+		return err
+	}
+	w.answers = append(w.answers, setting{name: s.setting, value: value})
+	return nil
+}
+
+func (w wizard) View() string {
+	if w.step == len(w.steps) || w.skipped || w.quitting {
+		return ""
+	}
+	view := wizardTitleStyle.Render("Welcome to timely! A few questions to get started") + "\n\n" +
+		helperStyle.Render(fmt.Sprintf("%d/%d ", w.step+1, len(w.steps))) + w.steps[w.step].question + "\n" +
+		w.input.View() + "\n"
+	if w.err != nil {
+		view += errorStyle.Render(w.err.Error()) + "\n"
+	}
+	return view + "\n" + helperStyle.Render("enter to accept the suggestion • esc to skip the setup • ctrl+c to quit") + "\n"
+}
+
+// runPlainWizard asks the steps of w line by line, for the plain mode. The
+// setup is skipped at the end of the input.
+func runPlainWizard(w wizard, scanner *bufio.Scanner, out io.Writer) wizard {
+	fmt.Fprintln(out, "Welcome to timely! A few questions to get started, an empty answer accepts the suggestion between brackets.")
+	for w.step < len(w.steps) {
+		s := w.steps[w.step]
+		fmt.Fprintf(out, "%s [%s] ", s.question, s.fallback)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			w.skipped = true
+			return w
+		}
+		if err := w.answer(scanner.Text()); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		w.step++
+	}
+	return w
+}
+
+// setUp runs the first-run setup and writes the answers to the configuration file
+// at path, even when skipped so that it is only run once. It returns false when
+// the user chose to quit instead.
+func setUp(path string, plain bool, in *bufio.Scanner) bool {
+	w := newWizard(wizardSteps())
+	if plain {
+		w = runPlainWizard(w, in, os.Stdout)
+	} else if final, err := tea.NewProgram(w).Run(); err == nil {
+		w = final.(wizard)
+	}
+	if w.quitting {
+		return false
+	}
+	if err := writeConfig(path, w.answers); err != nil {
+		fmt.Println("Cannot save the configuration:", err)
+		return true
+	}
+	fmt.Println("Configuration saved to", path)
+	return true
+}
+
+// isTerminal reports whether f is a terminal rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}